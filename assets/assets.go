@@ -3,17 +3,15 @@ package assets
 import (
 	"bufio"
 	"embed"
-	"encoding/csv"
 	"fmt"
 	"image"
 	_ "image/png"
 	"io/fs"
 	"os"
-	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/szkjn/snakeopoly-go/assets/fonts"
 	"golang.org/x/image/font"
-	"golang.org/x/image/font/opentype"
 )
 
 //go:embed *
@@ -22,20 +20,33 @@ var assets embed.FS
 var DataPointImg = MustLoadImage("images/30x30/user.png")
 var GooglevilImg = MustLoadImage("images/30x30/googlevil.png")
 
+// Return the embedded assets, laid out as the default campaign dir
+func FS() fs.FS {
+	return assets
+}
+
 func MustLoadImage(path string) *ebiten.Image {
-	f, err := assets.Open(path)
+	img, err := LoadImage(assets, path)
 	if err != nil {
 		panic(err)
 	}
+	return img
+}
+
+// Load an image from fsys
+func LoadImage(fsys fs.FS, path string) (*ebiten.Image, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
 	defer f.Close()
 
 	img, _, err := image.Decode(f)
 	if err != nil {
-		fmt.Printf("img: %v, f: %v\n", img, f)
-		panic(err)
+		return nil, fmt.Errorf("%s: %w", path, err)
 	}
 
-	return ebiten.NewImageFromImage(img)
+	return ebiten.NewImageFromImage(img), nil
 }
 
 func mustLoadImages(path string) []*ebiten.Image {
@@ -53,41 +64,7 @@ func mustLoadImages(path string) []*ebiten.Image {
 }
 
 func MustLoadFont(size float64) font.Face {
-	f, err := assets.ReadFile("fonts/VT323/VT323-Regular.ttf")
-	if err != nil {
-		panic(err)
-	}
-
-	tt, err := opentype.Parse(f)
-	if err != nil {
-		panic(err)
-	}
-
-	face, err := opentype.NewFace(tt, &opentype.FaceOptions{
-		Size:    float64(size),
-		DPI:     72,
-		Hinting: font.HintingVertical,
-	})
-	if err != nil {
-		panic(err)
-	}
-	return face
-}
-
-func LoadSpecialDataPointsCSV() ([][]string, error) {
-	fileData, err := assets.ReadFile("competitors.csv")
-	if err != nil {
-		return nil, err
-	}
-
-	reader := csv.NewReader(strings.NewReader(string(fileData)))
-	reader.Comma = ';'
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, err
-	}
-
-	return records, nil
+	return fonts.MustLoadFace(size)
 }
 
 func ReadAsciiArtFromFile(filePath string) ([]string, error) {
@@ -0,0 +1,29 @@
+package fonts
+
+import (
+	_ "embed"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+)
+
+//go:embed VT323/VT323-Regular.ttf
+var vt323 []byte
+
+// Load the game font at size, free of Ebiten so headless tools can measure texts with it
+func MustLoadFace(size float64) font.Face {
+	tt, err := opentype.Parse(vt323)
+	if err != nil {
+		panic(err)
+	}
+
+	face, err := opentype.NewFace(tt, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingVertical,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return face
+}
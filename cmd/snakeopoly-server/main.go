@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/szkjn/snakeopoly-go/content"
+	"github.com/szkjn/snakeopoly-go/leaderboard"
+)
+
+var (
+	addr       = flag.String("addr", ":8080", "address to listen on")
+	store      = flag.String("store", "leaderboard.json", "file keeping the leaderboard")
+	contentDir = flag.String("content", "assets/campaigns", "campaign dir, or dir of campaign dirs, the game is played with")
+)
+
+func runServer() error {
+	campaigns, err := content.LoadAll(os.DirFS(*contentDir))
+	if err != nil {
+		return err
+	}
+
+	fileStore, err := leaderboard.OpenFileStore(*store)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Leaderboard listening on %s", *addr)
+	return http.ListenAndServe(*addr, leaderboard.NewServer(fileStore, campaigns))
+}
+
+func main() {
+	flag.Parse()
+
+	if err := runServer(); err != nil {
+		log.Fatal(err)
+	}
+}
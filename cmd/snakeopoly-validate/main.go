@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/szkjn/snakeopoly-go/content"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: snakeopoly-validate <dir>")
+		os.Exit(2)
+	}
+	os.Exit(content.Report(os.Args[1], os.Stdout))
+}
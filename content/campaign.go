@@ -0,0 +1,287 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Name of the manifest at the root of a campaign dir
+const ManifestFile = "campaign.json"
+
+// Define the manifest of a campaign, naming its files relative to the campaign dir
+type Manifest struct {
+	Name         string
+	DataPoints   string                    // CSV file of the special data points, defaults to competitors.csv
+	Icons        string                    // Dir of the <slug>.png icons, defaults to icons
+	Snake        string                    // Sprite of the snake segments, empty for the default one
+	Shape        string                    // Text grid of the welcome page shape, '#' for a filled pixel, empty for the default one
+	Layouts      map[string]string         // Text grid of the walls of each level, '#' for a wall cell, by level name
+	Speeds       map[string]sim.SpeedCurve // Speed curve of the snake by difficulty name, the missing ones falling back to the default ones
+	Behaviors    sim.Behaviors             // Behavior of the regular data points by level name, expiring or fleeing the snake
+	Regulator    sim.Regulation            // Antitrust regulator hunting the snake from one of the levels on
+	Copy         Copy
+	Translations map[string]Copy // Copy by language, empty texts falling back to Copy
+	Themes       Themes
+}
+
+// Define the narrative texts of a campaign
+type Copy struct {
+	Title    string   // Title of the welcome page
+	Tagline  []string // Up to 2 lines under the title
+	GameOver []string // Up to 3 lines of the game over page
+	Goal     []string // Up to 3 lines of the goal page
+	Motto    string   // Closing line of the goal page
+}
+
+// Define the color themes of a campaign
+type Themes struct {
+	Day        Theme // Theme of the play page
+	Night      Theme
+	Apocalypse Theme // Theme of the special, game over and goal pages
+}
+
+// Define a color theme, each color as #rrggbb or empty for the default one
+type Theme struct {
+	Background  string
+	Grid        string
+	DrawElement string
+}
+
+// Maximum number of lines of each part of the copy
+const (
+	MaxTaglineLines  = 2
+	MaxGameOverLines = 3
+	MaxGoalLines     = 3
+)
+
+// Copy of the original Google campaign, used for every text a manifest leaves empty
+var DefaultCopy = Copy{
+	Tagline:  []string{"Slither your way", "to Surveillance Sovereignty!"},
+	GameOver: []string{"Oops! You've been out-monopolized.", "But don't worry, your data", "will live on forever with us."},
+	Goal:     []string{"Master of the Digital Panopticon !", "In the world of Surveillance Capitalism,", "you stand unrivaled !"},
+	Motto:    "A true data supremacist !!!",
+}
+
+// Define a campaign loaded from a content dir
+type Campaign struct {
+	Manifest
+	FS                fs.FS
+	SpecialDataPoints []sim.SpecialDataPoint
+	Layouts           sim.Layouts
+	Speeds            sim.SpeedCurves
+}
+
+// Load the campaign at the root of fsys
+func Load(fsys fs.FS) (*Campaign, error) {
+	manifest, err := ReadManifest(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := fsys.Open(manifest.DataPoints)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	specialDataPoints, err := sim.ReadSpecialDataPoints(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", manifest.DataPoints, err)
+	}
+
+	layouts := sim.Layouts{}
+	for level, name := range manifest.Layouts {
+		layout, err := ReadLayout(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		layouts[level] = layout
+	}
+
+	speeds := sim.SpeedCurves{}
+	for name, curve := range manifest.Speeds {
+		difficulty, err := sim.ParseDifficulty(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: speed curve: %w", ManifestFile, err)
+		}
+		speeds[difficulty] = curve
+	}
+
+	return &Campaign{Manifest: manifest, FS: fsys, SpecialDataPoints: specialDataPoints, Layouts: layouts, Speeds: speeds}, nil
+}
+
+// Load the campaign at the root of fsys, or else every campaign in its subdirs
+func LoadAll(fsys fs.FS) ([]*Campaign, error) {
+	if _, err := fs.Stat(fsys, ManifestFile); err == nil {
+		campaign, err := Load(fsys)
+		if err != nil {
+			return nil, err
+		}
+		return []*Campaign{campaign}, nil
+	}
+
+	dirs, err := CampaignDirs(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	var campaigns []*Campaign
+	for _, dir := range dirs {
+		sub, err := fs.Sub(fsys, dir)
+		if err != nil {
+			return nil, err
+		}
+		campaign, err := Load(sub)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", dir, err)
+		}
+		campaigns = append(campaigns, campaign)
+	}
+	return campaigns, nil
+}
+
+// List the subdirs of fsys holding a manifest, in name order
+func CampaignDirs(fsys fs.FS) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if _, err := fs.Stat(fsys, path.Join(entry.Name(), ManifestFile)); entry.IsDir() && err == nil {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, errors.New("no campaign found")
+	}
+	return dirs, nil
+}
+
+// Read the manifest at the root of fsys, filling in the default file names and copy
+func ReadManifest(fsys fs.FS) (Manifest, error) {
+	var manifest Manifest
+	fileData, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(fileData, &manifest); err != nil {
+		return manifest, fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+	if manifest.Name == "" {
+		return manifest, fmt.Errorf("%s: missing name", ManifestFile)
+	}
+
+	if manifest.DataPoints == "" {
+		manifest.DataPoints = "competitors.csv"
+	}
+	if manifest.Icons == "" {
+		manifest.Icons = "icons"
+	}
+	if manifest.Copy.Title == "" {
+		manifest.Copy.Title = fmt.Sprintf("Welcome to %s's Snakeopoly!", manifest.Name)
+	}
+	if manifest.Copy.Tagline == nil {
+		manifest.Copy.Tagline = DefaultCopy.Tagline
+	}
+	if manifest.Copy.GameOver == nil {
+		manifest.Copy.GameOver = DefaultCopy.GameOver
+	}
+	if manifest.Copy.Goal == nil {
+		manifest.Copy.Goal = DefaultCopy.Goal
+	}
+	if manifest.Copy.Motto == "" {
+		manifest.Copy.Motto = DefaultCopy.Motto
+	}
+	return manifest, nil
+}
+
+// Return the identifier of the campaign, its lowercased name with dashes for spaces
+func (m Manifest) ID() string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(m.Name)), " ", "-")
+}
+
+// Return the copy in lang, the texts it does not translate being left untranslated
+func (m Manifest) CopyFor(lang string) Copy {
+	texts := m.Copy
+	translation, found := m.Translations[lang]
+	if !found {
+		return texts
+	}
+	if translation.Title != "" {
+		texts.Title = translation.Title
+	}
+	if translation.Tagline != nil {
+		texts.Tagline = translation.Tagline
+	}
+	if translation.GameOver != nil {
+		texts.GameOver = translation.GameOver
+	}
+	if translation.Goal != nil {
+		texts.Goal = translation.Goal
+	}
+	if translation.Motto != "" {
+		texts.Motto = translation.Motto
+	}
+	return texts
+}
+
+// Return the path of the icon of a special data point
+func (m Manifest) IconPath(slug string) string {
+	return path.Join(m.Icons, slug+".png")
+}
+
+// Read a text grid shape, '#' being a filled pixel and any other character an empty one
+func ReadShape(fsys fs.FS, name string) ([][]int, error) {
+	fileData, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var shape [][]int
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(string(fileData), "\r\n", "\n"), "\n"), "\n")
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			return nil, fmt.Errorf("%s:%d: expected %d columns, got %d", name, i+1, len(lines[0]), len(line))
+		}
+		row := make([]int, len(line))
+		for j, char := range line {
+			if char == '#' {
+				row[j] = 1
+			}
+		}
+		shape = append(shape, row)
+	}
+	return shape, nil
+}
+
+// Read the text grid of the walls of a level, sim.LayoutWidth by sim.LayoutHeight cells, '#' being a wall
+func ReadLayout(fsys fs.FS, name string) (sim.Layout, error) {
+	grid, err := ReadShape(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := sim.NewLayout(grid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return layout, nil
+}
+
+// Parse a color written as #rrggbb
+func ParseColor(hex string) (color.RGBA, error) {
+	var c color.RGBA
+	if _, err := fmt.Sscanf(hex, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil || len(hex) != 7 {
+		return c, fmt.Errorf("invalid color %q, expected #rrggbb", hex)
+	}
+	c.A = 255
+	return c, nil
+}
@@ -0,0 +1,447 @@
+package content
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/png"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/typeset"
+)
+
+// Largest icon, in pixels, drawn without being cut by the grid cell
+const MaxIconSize = 30
+
+// Columns of the special data points CSV, in any order, the optional ones being left out or empty
+var (
+	RequiredColumns = sim.RequiredColumns
+	OptionalColumns = []string{"growth", "value", "price", "category", "summary", "sources"}
+)
+
+// Prefixes of the optional columns translating a text, followed by a language
+var LocalizedColumns = []string{"text_", "level_", "summary_", "category_"}
+
+// Define a problem found in a campaign, Line being 0 when it concerns a whole file
+type Diagnostic struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+}
+
+// Check the campaign at the root of fsys, using textFits to check that a text fits its page
+func Validate(fsys fs.FS, textFits func(column, text string) bool) []Diagnostic {
+	manifest, err := ReadManifest(fsys)
+	if err != nil {
+		return []Diagnostic{{File: ManifestFile, Message: err.Error()}}
+	}
+
+	file, err := fsys.Open(manifest.DataPoints)
+	if err != nil {
+		return []Diagnostic{{File: manifest.DataPoints, Message: err.Error()}}
+	}
+	defer file.Close()
+
+	v := validator{fsys: fsys, manifest: manifest, textFits: textFits}
+	v.checkManifest()
+	v.checkDataPoints(file)
+	v.checkLayouts()
+	v.checkSpeeds()
+	v.checkBehaviors()
+	v.checkRegulator()
+	return v.diagnostics
+}
+
+// Check the campaign at the root of fsys, or else every campaign in its subdirs
+func ValidateAll(fsys fs.FS, textFits func(column, text string) bool) []Diagnostic {
+	if _, err := fs.Stat(fsys, ManifestFile); err == nil {
+		return Validate(fsys, textFits)
+	}
+
+	dirs, err := CampaignDirs(fsys)
+	if err != nil {
+		return []Diagnostic{{File: ManifestFile, Message: err.Error()}}
+	}
+
+	var diagnostics []Diagnostic
+	ids := map[string]string{}
+	for _, dir := range dirs {
+		sub, err := fs.Sub(fsys, dir)
+		if err != nil {
+			return append(diagnostics, Diagnostic{File: dir, Message: err.Error()})
+		}
+		for _, diagnostic := range Validate(sub, textFits) {
+			diagnostic.File = path.Join(dir, diagnostic.File)
+			diagnostics = append(diagnostics, diagnostic)
+		}
+
+		// Campaigns are told apart by ID in saves, replays and leaderboards
+		if manifest, err := ReadManifest(sub); err == nil {
+			if other, found := ids[manifest.ID()]; found {
+				diagnostics = append(diagnostics, Diagnostic{File: path.Join(dir, ManifestFile), Message: fmt.Sprintf("campaign ID %q already used by %s", manifest.ID(), other)})
+			}
+			ids[manifest.ID()] = dir
+		}
+	}
+	return diagnostics
+}
+
+type validator struct {
+	fsys        fs.FS
+	manifest    Manifest
+	textFits    func(column, text string) bool
+	levels      map[string]bool // Levels of the special data points
+	diagnostics []Diagnostic
+}
+
+func (v *validator) report(line int, format string, args ...any) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{File: v.manifest.DataPoints, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) reportManifest(format string, args ...any) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{File: ManifestFile, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) checkManifest() {
+	if v.manifest.Snake != "" {
+		if err := v.checkImage(v.manifest.Snake); err != nil {
+			v.reportManifest("snake: %v", err)
+		}
+	}
+	if v.manifest.Shape != "" {
+		if _, err := ReadShape(v.fsys, v.manifest.Shape); err != nil {
+			v.reportManifest("shape: %v", err)
+		}
+	}
+
+	v.checkCopy("", v.manifest.Copy)
+	langs := make([]string, 0, len(v.manifest.Translations))
+	for lang := range v.manifest.Translations {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		v.checkCopy(lang+" ", v.manifest.Translations[lang])
+	}
+
+	themes := v.manifest.Themes
+	for i, theme := range []Theme{themes.Day, themes.Night, themes.Apocalypse} {
+		for _, hex := range []string{theme.Background, theme.Grid, theme.DrawElement} {
+			if _, err := ParseColor(hex); hex != "" && err != nil {
+				v.reportManifest("%s theme: %v", []string{"day", "night", "apocalypse"}[i], err)
+			}
+		}
+	}
+}
+
+// Check that every layout parses and belongs to a level of the special data points
+func (v *validator) checkLayouts() {
+	levels := make([]string, 0, len(v.manifest.Layouts))
+	for level := range v.manifest.Layouts {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	for _, level := range levels {
+		if _, err := ReadLayout(v.fsys, v.manifest.Layouts[level]); err != nil {
+			v.reportManifest("layout of %q: %v", level, err)
+		}
+		if v.levels != nil && !v.levels[level] {
+			v.reportManifest("layout for unknown level %q", level)
+		}
+	}
+}
+
+// Check that every speed curve belongs to a difficulty and speeds the snake up from a positive speed
+func (v *validator) checkSpeeds() {
+	names := make([]string, 0, len(v.manifest.Speeds))
+	for name := range v.manifest.Speeds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		curve := v.manifest.Speeds[name]
+		if _, err := sim.ParseDifficulty(name); err != nil {
+			v.reportManifest("speed curve: %v", err)
+		}
+		if curve.Start <= 0 {
+			v.reportManifest("speed curve of %q starts at %g moves/s, must be positive", name, curve.Start)
+		}
+		if curve.PerPickup < 0 || curve.PerLevel < 0 {
+			v.reportManifest("speed curve of %q slows the snake down", name)
+		}
+		if curve.Max != 0 && curve.Max < curve.Start {
+			v.reportManifest("speed curve of %q is capped at %g moves/s, below its start", name, curve.Max)
+		}
+	}
+}
+
+// Check that every behavior belongs to a level of the special data points and sets counts and moves that fit
+func (v *validator) checkBehaviors() {
+	levels := make([]string, 0, len(v.manifest.Behaviors))
+	for level := range v.manifest.Behaviors {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	for _, level := range levels {
+		behavior := v.manifest.Behaviors[level]
+		if behavior.Count < 0 || behavior.Lifetime < 0 || behavior.FleePeriod < 0 {
+			v.reportManifest("behavior of %q: count, lifetime and flee period must not be negative", level)
+		}
+		if behavior.Count > sim.MaxDataPoints {
+			v.reportManifest("behavior of %q has %d data points, at most %d fit", level, behavior.Count, sim.MaxDataPoints)
+		}
+		if v.levels != nil && !v.levels[level] {
+			v.reportManifest("behavior for unknown level %q", level)
+		}
+	}
+}
+
+// Check that the regulator shows up at a level of the special data points and enforces positive amounts
+func (v *validator) checkRegulator() {
+	regulation := v.manifest.Regulator
+	if regulation == (sim.Regulation{}) {
+		return
+	}
+	if regulation.Level == "" {
+		v.reportManifest("regulator without a level")
+	} else if v.levels != nil && !v.levels[regulation.Level] {
+		v.reportManifest("regulator at unknown level %q", regulation.Level)
+	}
+	if regulation.Period < 0 || regulation.Fine < 0 || regulation.Cut < 0 {
+		v.reportManifest("regulator period, fine and cut must not be negative")
+	}
+}
+
+// Check the line counts of texts, prefixing diagnostics with prefix
+func (v *validator) checkCopy(prefix string, texts Copy) {
+	if len(texts.Tagline) > MaxTaglineLines {
+		v.reportManifest("%stagline has %d lines, at most %d fit", prefix, len(texts.Tagline), MaxTaglineLines)
+	}
+	if len(texts.GameOver) > MaxGameOverLines {
+		v.reportManifest("%sgame over copy has %d lines, at most %d fit", prefix, len(texts.GameOver), MaxGameOverLines)
+	}
+	if len(texts.Goal) > MaxGoalLines {
+		v.reportManifest("%sgoal copy has %d lines, at most %d fit", prefix, len(texts.Goal), MaxGoalLines)
+	}
+}
+
+func (v *validator) checkDataPoints(r io.Reader) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		v.report(1, "cannot read header: %v", err)
+		return
+	}
+	columns, valid := v.checkHeader(header)
+	if !valid {
+		return
+	}
+
+	slugLines := map[string]int{}
+	levelLines := map[string]int{}
+	v.levels = map[string]bool{}
+	previousLevel := ""
+	previousYear := 0
+	rows := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			v.report(parseErr.Line, "%v", parseErr.Err)
+			continue
+		} else if err != nil {
+			v.report(0, "%v", err)
+			return
+		}
+		line, _ := reader.FieldPos(0)
+		rows++
+
+		if len(record) != len(header) {
+			v.report(line, "expected %d columns, got %d", len(header), len(record))
+			continue
+		}
+		name, slug, yearText, level := record[columns["name"]], record[columns["slug"]], record[columns["year"]], record[columns["level"]]
+
+		if strings.TrimSpace(name) == "" {
+			v.report(line, "empty name")
+		}
+
+		if slug == "" || strings.Trim(slug, "abcdefghijklmnopqrstuvwxyz0123456789_-") != "" {
+			v.report(line, "slug %q must be lowercase letters, digits, '_' or '-'", slug)
+		} else if firstLine, found := slugLines[slug]; found {
+			v.report(line, "duplicate slug %q, first used on line %d", slug, firstLine)
+		} else {
+			slugLines[slug] = line
+			v.checkIcon(line, slug)
+		}
+
+		year, err := strconv.Atoi(yearText)
+		if err != nil {
+			v.report(line, "invalid year %q", yearText)
+		} else {
+			if year < previousYear {
+				v.report(line, "year %d comes before the previous acquisition (%d)", year, previousYear)
+			}
+			previousYear = year
+		}
+
+		if strings.TrimSpace(record[columns["text"]]) == "" {
+			v.report(line, "empty text")
+		}
+
+		// Levels only ever move forward
+		if strings.TrimSpace(level) == "" {
+			v.report(line, "empty level")
+		} else if firstLine, found := levelLines[level]; found && level != previousLevel {
+			v.report(line, "level %q comes back after another level, first used on line %d", level, firstLine)
+		} else if !found {
+			levelLines[level] = line
+			v.levels[level] = true
+		}
+		previousLevel = level
+
+		for i, column := range header {
+			v.checkValue(line, strings.ToLower(strings.TrimSpace(column)), record[i])
+		}
+	}
+
+	if rows == 0 {
+		v.report(0, "no special data points")
+	}
+}
+
+// Check a single value of a row against what its column holds
+func (v *validator) checkValue(line int, column, value string) {
+	// Translations are checked as the column they translate
+	base, _, localized := strings.Cut(column, "_")
+	if localized && value == "" && base == "level" {
+		v.report(line, "empty %s", column)
+	}
+	if value == "" {
+		return
+	}
+
+	if base == "growth" || base == "value" {
+		if n, err := strconv.ParseInt(value, 10, 64); err != nil || n < 0 {
+			v.report(line, "invalid %s %q", column, value)
+		}
+	} else if base == "sources" {
+		for _, source := range strings.Split(value, sim.SourcesSeparator) {
+			if strings.TrimSpace(source) == "" {
+				v.report(line, "empty source in %q", value)
+			}
+		}
+	} else if base == "text" || base == "summary" || base == "category" || base == "price" {
+		if v.textFits != nil && !v.textFits(base, value) {
+			v.report(line, "%s of %d characters does not fit its page", column, utf8.RuneCountInString(value))
+		}
+	}
+}
+
+// Check the columns of the header, returning the index of each column by name and false if rows cannot be checked against it
+func (v *validator) checkHeader(header []string) (map[string]int, bool) {
+	columns := map[string]int{}
+	valid := true
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if _, found := columns[column]; found {
+			v.report(1, "duplicate column %q", column)
+			valid = false
+		} else if !isRequiredColumn(column) && !isOptionalColumn(column) {
+			v.report(1, "unknown column %d %q, expected one of %s or %s<lang>", i+1, column, strings.Join(OptionalColumns, ", "), strings.Join(LocalizedColumns, "<lang>, "))
+			valid = false
+		}
+		columns[column] = i
+	}
+
+	// The columns are found by name, in any order
+	for _, required := range RequiredColumns {
+		if _, found := columns[required]; !found {
+			v.report(1, "missing column %q", required)
+			valid = false
+		}
+	}
+	return columns, valid
+}
+
+func isRequiredColumn(column string) bool {
+	for _, required := range RequiredColumns {
+		if column == required {
+			return true
+		}
+	}
+	return false
+}
+
+func isOptionalColumn(column string) bool {
+	for _, optional := range OptionalColumns {
+		if column == optional {
+			return true
+		}
+	}
+	for _, prefix := range LocalizedColumns {
+		if lang, found := strings.CutPrefix(column, prefix); found && lang != "" && strings.Trim(lang, "abcdefghijklmnopqrstuvwxyz") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *validator) checkIcon(line int, slug string) {
+	if err := v.checkImage(v.manifest.IconPath(slug)); err != nil {
+		v.report(line, "icon: %v", err)
+	}
+}
+
+// Check that the image at name exists and fits a grid cell
+func (v *validator) checkImage(name string) error {
+	file, err := v.fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("missing %s", name)
+	}
+	defer file.Close()
+
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return fmt.Errorf("unreadable %s: %v", name, err)
+	} else if config.Width > MaxIconSize || config.Height > MaxIconSize {
+		return fmt.Errorf("%s is %dx%d, larger than %dx%d", name, config.Width, config.Height, MaxIconSize, MaxIconSize)
+	}
+	return nil
+}
+
+// Print the diagnostics of the campaigns in dir to w, returning the exit code
+func Report(dir string, w io.Writer) int {
+	diagnostics := ValidateAll(os.DirFS(dir), typeset.ContentFits)
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintln(w, diagnostic)
+	}
+	if len(diagnostics) > 0 {
+		fmt.Fprintf(w, "%d problem(s) found\n", len(diagnostics))
+		return 1
+	}
+	fmt.Fprintf(w, "%s: ok\n", dir)
+	return 0
+}
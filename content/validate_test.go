@@ -0,0 +1,130 @@
+package content_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/szkjn/snakeopoly-go/content"
+)
+
+// Return a PNG image of width by height pixels
+func icon(t *testing.T, width, height int) *fstest.MapFile {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return &fstest.MapFile{Data: buf.Bytes()}
+}
+
+// Return a campaign with a manifest, the rows of its special data points CSV under header, and an icon per row
+func campaign(t *testing.T, manifest, header string, rows ...string) fstest.MapFS {
+	t.Helper()
+	fsys := fstest.MapFS{
+		content.ManifestFile: {Data: []byte(manifest)},
+		"competitors.csv":    {Data: []byte(strings.Join(append([]string{header}, rows...), "\n") + "\n")},
+	}
+	for _, row := range rows {
+		fsys["icons/"+strings.Split(row, ";")[1]+".png"] = icon(t, content.MaxIconSize, content.MaxIconSize)
+	}
+	return fsys
+}
+
+func TestValidate(t *testing.T) {
+	const manifest = `{"Name": "Test"}`
+	const header = "name;slug;year;text;level"
+	tests := []struct {
+		name  string
+		fsys  fstest.MapFS
+		fits  func(column, text string) bool
+		wants []string // Diagnostics, as substrings, in order
+	}{
+		{
+			name: "valid",
+			fsys: campaign(t, manifest, header, "A;a;2001;Hello;One", "B;b;2002;Hello;Two"),
+		},
+		{
+			name: "reordered columns",
+			fsys: campaign(t, manifest, "level;slug;name;text;year", "One;a;A;Hello;2001"),
+		},
+		{
+			name:  "unknown header",
+			fsys:  campaign(t, manifest, header+";colour", "A;a;2001;Hello;One;red"),
+			wants: []string{`competitors.csv:1: unknown column 6 "colour"`},
+		},
+		{
+			name:  "missing header",
+			fsys:  campaign(t, manifest, "name;slug;year;level", "A;a;2001;One"),
+			wants: []string{`competitors.csv:1: missing column "text"`},
+		},
+		{
+			name:  "duplicate slug",
+			fsys:  campaign(t, manifest, header, "A;a;2001;Hello;One", "B;a;2002;Hello;One"),
+			wants: []string{`competitors.csv:3: duplicate slug "a", first used on line 2`},
+		},
+		{
+			name:  "years out of order",
+			fsys:  campaign(t, manifest, header, "A;a;2005;Hello;One", "B;b;2002;Hello;One"),
+			wants: []string{"competitors.csv:3: year 2002 comes before the previous acquisition (2005)"},
+		},
+		{
+			name:  "returning level",
+			fsys:  campaign(t, manifest, header, "A;a;2001;Hello;One", "B;b;2002;Hello;Two", "C;c;2003;Hello;One"),
+			wants: []string{`competitors.csv:4: level "One" comes back after another level, first used on line 2`},
+		},
+		{
+			name: "oversized icon",
+			fsys: func() fstest.MapFS {
+				fsys := campaign(t, manifest, header, "A;a;2001;Hello;One")
+				fsys["icons/a.png"] = icon(t, 40, 40)
+				return fsys
+			}(),
+			wants: []string{"competitors.csv:2: icon: icons/a.png is 40x40"},
+		},
+		{
+			name: "missing icon",
+			fsys: func() fstest.MapFS {
+				fsys := campaign(t, manifest, header, "A;a;2001;Hello;One")
+				delete(fsys, "icons/a.png")
+				return fsys
+			}(),
+			wants: []string{"competitors.csv:2: icon: "},
+		},
+		{
+			name:  "too long text",
+			fsys:  campaign(t, manifest, header+";text_fr", "A;a;2001;Hello;One;Bonjour tout le monde"),
+			fits:  func(column, text string) bool { return len(text) <= 10 },
+			wants: []string{"competitors.csv:2: text_fr of 21 characters does not fit its page"},
+		},
+		{
+			name:  "too long tagline",
+			fsys:  campaign(t, `{"Name": "Test", "Copy": {"Tagline": ["1", "2", "3", "4", "5", "6", "7", "8", "9"]}}`, header, "A;a;2001;Hello;One"),
+			wants: []string{"campaign.json: tagline has 9 lines"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diagnostics := content.Validate(test.fsys, test.fits)
+			if len(diagnostics) != len(test.wants) {
+				t.Fatalf("got %d diagnostics %v, want %d %q", len(diagnostics), diagnostics, len(test.wants), test.wants)
+			}
+			for i, want := range test.wants {
+				if got := diagnostics[i].String(); !strings.HasPrefix(got, want) {
+					t.Errorf("diagnostic %d = %q, want it to start with %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateEmbeddedCampaigns(t *testing.T) {
+	var out bytes.Buffer
+	if code := content.Report("../assets/campaigns", &out); code != 0 {
+		t.Fatalf("Report = %d, output:\n%s", code, out.String())
+	}
+}
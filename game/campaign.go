@@ -0,0 +1,128 @@
+package game
+
+import (
+	"image/color"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/szkjn/snakeopoly-go/assets"
+	"github.com/szkjn/snakeopoly-go/content"
+)
+
+// Campaign selected when none is asked for
+const DefaultCampaign = "google"
+
+// Define a campaign with everything the game draws for it
+type Campaign struct {
+	*content.Campaign
+	SnakeImg        *ebiten.Image
+	Shape           [][]int // Shape alternating with the 666 on the welcome page
+	DayTheme        ColorTheme
+	NightTheme      ColorTheme
+	ApocalypseTheme ColorTheme
+	icons           map[string]*ebiten.Image // Images of the special data points, keyed by slug
+}
+
+// Load the campaigns of the content dir, falling back to the embedded ones if dir is empty or broken
+func LoadCampaigns(dir string) ([]*Campaign, error) {
+	if dir != "" {
+		campaigns, err := loadCampaigns(os.DirFS(dir))
+		if err == nil {
+			return campaigns, nil
+		}
+		log.Printf("Failed to load content from %s, using the embedded campaigns: %v", dir, err)
+	}
+
+	embedded, err := fs.Sub(assets.FS(), "campaigns")
+	if err != nil {
+		return nil, err
+	}
+	return loadCampaigns(embedded)
+}
+
+func loadCampaigns(fsys fs.FS) ([]*Campaign, error) {
+	contents, err := content.LoadAll(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns := make([]*Campaign, len(contents))
+	for i, c := range contents {
+		campaigns[i] = NewCampaign(c)
+	}
+	return campaigns, nil
+}
+
+// Load the images of a campaign, drawing placeholders for missing icons and defaults for the rest
+func NewCampaign(c *content.Campaign) *Campaign {
+	campaign := &Campaign{
+		Campaign:        c,
+		SnakeImg:        assets.GooglevilImg,
+		Shape:           GShape,
+		DayTheme:        themeOf(c.Themes.Day, DayTheme),
+		NightTheme:      themeOf(c.Themes.Night, NightTheme),
+		ApocalypseTheme: themeOf(c.Themes.Apocalypse, ApocalypseTheme),
+		icons:           map[string]*ebiten.Image{},
+	}
+
+	if c.Snake != "" {
+		img, err := assets.LoadImage(c.FS, c.Snake)
+		if err != nil {
+			log.Printf("Missing snake of %s: %v", c.Name, err)
+		} else {
+			campaign.SnakeImg = img
+		}
+	}
+	if c.Shape != "" {
+		shape, err := content.ReadShape(c.FS, c.Shape)
+		if err != nil {
+			log.Printf("Invalid shape of %s: %v", c.Name, err)
+		} else {
+			campaign.Shape = shape
+		}
+	}
+
+	for _, specialDataPoint := range c.SpecialDataPoints {
+		img, err := assets.LoadImage(c.FS, c.IconPath(specialDataPoint.Slug))
+		if err != nil {
+			log.Printf("Missing icon for %s: %v", specialDataPoint.Slug, err)
+			img = PlaceholderIcon(specialDataPoint.Name)
+		}
+		campaign.icons[specialDataPoint.Slug] = img
+	}
+
+	return campaign
+}
+
+// Return the theme with the colors set in the manifest, keeping the fallback ones elsewhere
+func themeOf(theme content.Theme, fallback ColorTheme) ColorTheme {
+	pick := func(hex string, fallback color.Color) color.Color {
+		if hex == "" {
+			return fallback
+		}
+		c, err := content.ParseColor(hex)
+		if err != nil {
+			log.Printf("Invalid theme color: %v", err)
+			return fallback
+		}
+		return c
+	}
+
+	return ColorTheme{
+		Background:  pick(theme.Background, fallback.Background),
+		Grid:        pick(theme.Grid, fallback.Grid),
+		DrawElement: pick(theme.DrawElement, fallback.DrawElement),
+	}
+}
+
+// Return the index of the campaign with the given ID, 0 if there is none
+func campaignIndex(campaigns []*Campaign, id string) int {
+	for i, campaign := range campaigns {
+		if campaign.ID() == id {
+			return i
+		}
+	}
+	return 0
+}
@@ -2,32 +2,36 @@ package game
 
 import (
 	"image/color"
-	"time"
 
 	"github.com/szkjn/snakeopoly-go/assets"
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/typeset"
 	"golang.org/x/image/font"
 )
 
 // Constants related to screen and play area dimensions
 const (
 	ScreenRatio    float32 = 5.0 / 4.0
-	ScreenWidth    float32 = 800.0
+	ScreenWidth    float32 = typeset.ScreenWidth
 	ScreenHeight   float32 = ScreenWidth / ScreenRatio
-	ScreenUnit     float32 = ScreenWidth / 25
-	PlayAreaWidth  float32 = ScreenWidth - (ScreenUnit * 2)
-	PlayAreaHeight float32 = ScreenHeight - (ScreenUnit * 5)
-	PlayAreaX1     float32 = ScreenUnit
-	PlayAreaY1     float32 = ScreenUnit
-	PlayAreaX2     float32 = PlayAreaX1 + PlayAreaWidth
-	PlayAreaY2     float32 = PlayAreaY1 + PlayAreaHeight
+	ScreenUnit     float32 = typeset.ScreenUnit
+	PlayAreaX1     float32 = ScreenUnit * sim.GridX1
+	PlayAreaY1     float32 = ScreenUnit * sim.GridY1
+	PlayAreaX2     float32 = ScreenUnit * sim.GridX2
+	PlayAreaY2     float32 = ScreenUnit * sim.GridY2
+	PlayAreaWidth  float32 = PlayAreaX2 - PlayAreaX1
+	PlayAreaHeight float32 = PlayAreaY2 - PlayAreaY1
 )
 
 // Constants related to the snake and data points
 const (
-	SnakeSize             float32 = ScreenUnit
-	InitialSnakeLength    float32 = 3
-	SnakeSpeed            float32 = 7
-	SpecialDataPointsRate int8    = 3
+	SnakeSize float32 = ScreenUnit
+)
+
+// Constants related to the fixed-timestep tick loop
+const (
+	TPS             int = 60 // Ticks per second
+	MaxCatchUpTicks int = 5  // Maximum ticks run in a single update after a hitch
 )
 
 // Colors
@@ -46,17 +50,25 @@ var (
 	FontXXL font.Face = assets.MustLoadFont(float64(ScreenUnit * 1.9))
 	FontXL  font.Face = assets.MustLoadFont(float64(ScreenUnit * 1.6))
 	FontL   font.Face = assets.MustLoadFont(float64(ScreenUnit * 1.3))
-	FontM   font.Face = assets.MustLoadFont(float64(ScreenUnit * 1))
-	FontS   font.Face = assets.MustLoadFont(float64(ScreenUnit * 0.7))
+	FontM   font.Face = typeset.FontM
+	FontS   font.Face = typeset.FontS
 	FontXS  font.Face = assets.MustLoadFont(float64(ScreenUnit * 0.3))
 )
 
-// UI effects
+// UI effects, timers expressed in ticks
+const (
+	TotalBlinkDuration int     = TPS          // 1s
+	BlinkFreq          int     = TPS / 5      // 200ms
+	TextAnimationSpeed int     = TPS * 3 / 10 // 300ms per revealed character
+	ShapePixelSize     float64 = float64(ScreenUnit) / 6
+	CampaignShapeTime  int     = 2 * TPS     // 2s
+	SixShapeTime       int     = TPS * 2 / 5 // 400ms
+	ExpiryWarningMoves int     = 8           // Moves left when an expiring data point starts blinking
+)
+
+// Constants related to the timeline of the goal page
 const (
-	TotalBlinkDuration time.Duration = 1 * time.Second
-	BlinkFreq          time.Duration = 200 * time.Millisecond
-	TextAnimationSpeed time.Duration = 300 * time.Millisecond
-	ShapePixelSize     float64       = float64(ScreenUnit) / 6
-	GShapeTime         time.Duration = 2000 * time.Millisecond
-	SixShapeTime       time.Duration = 400 * time.Millisecond
+	TimelineRows       int     = 7       // Acquisitions shown at once
+	TimelineY          float32 = 8.7     // Row of the first acquisition, in screen units
+	TimelineRevealTime int     = TPS / 3 // Ticks between two acquisitions appearing
 )
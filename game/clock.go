@@ -0,0 +1,56 @@
+package game
+
+import (
+	"time"
+)
+
+// Define a source of wall-clock time, injectable for tests and replays
+type Clock interface {
+	Now() time.Time
+}
+
+// Define the Clock backed by the system time
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Define a fixed-timestep scheduler turning elapsed clock time into whole ticks
+type Scheduler struct {
+	Clock        Clock
+	TickDuration time.Duration
+	MaxCatchUp   int // Maximum number of ticks run in a single update
+	lastTime     time.Time
+	accumulator  time.Duration
+}
+
+// Initialize and return a new scheduler running tps ticks per second
+func NewScheduler(clock Clock, tps int) *Scheduler {
+	return &Scheduler{
+		Clock:        clock,
+		TickDuration: time.Second / time.Duration(tps),
+		MaxCatchUp:   MaxCatchUpTicks,
+		lastTime:     clock.Now(),
+	}
+}
+
+// Return the number of ticks due since the previous call
+func (s *Scheduler) Advance() int {
+	now := s.Clock.Now()
+	s.accumulator += now.Sub(s.lastTime)
+	s.lastTime = now
+
+	ticks := 0
+	for s.accumulator >= s.TickDuration && ticks < s.MaxCatchUp {
+		s.accumulator -= s.TickDuration
+		ticks++
+	}
+
+	// Drop the backlog after a long hitch instead of fast-forwarding the game
+	if ticks == s.MaxCatchUp {
+		s.accumulator = 0
+	}
+
+	return ticks
+}
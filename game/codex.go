@@ -0,0 +1,56 @@
+package game
+
+import (
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Maximum number of icons shown at once on the codex page
+const CodexStripSize = 20
+
+// Read the codex, empty if nothing has been unlocked yet
+func LoadCodex() (sim.Codex, error) {
+	codex := sim.Codex{}
+	if _, err := readConfigJSON("codex.json", &codex); err != nil {
+		return sim.Codex{}, err
+	}
+	return codex, nil
+}
+
+// Write the codex
+func WriteCodex(codex sim.Codex) error {
+	return writeConfigJSON("codex.json", codex)
+}
+
+// Add the special data point just acquired to the codex
+func (g *Game) unlockSpecial(special SpecialDataPoint) {
+	if !g.Codex.Unlock(g.Campaign.ID(), special.Slug) || !g.persist {
+		return
+	}
+	if err := WriteCodex(g.Codex); err != nil {
+		log.Printf("Failed to save codex: %v", err)
+	}
+}
+
+// Open the codex on the first entry of the current campaign
+func (g *Game) openCodex() {
+	g.CodexIndex = 0
+	g.State = CodexState
+}
+
+// Browse the codex entries with the arrow keys
+func (g *Game) handleCodexInput() {
+	count := len(g.Campaign.SpecialDataPoints)
+	if count == 0 {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) || inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		g.CodexIndex = (g.CodexIndex + 1) % count
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) || inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		g.CodexIndex = (g.CodexIndex + count - 1) % count
+	}
+}
@@ -1,124 +1,58 @@
 package game
 
 import (
-	"math/rand"
-	"strconv"
+	"image"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
 	"github.com/szkjn/snakeopoly-go/assets"
+	"github.com/szkjn/snakeopoly-go/sim"
+	"golang.org/x/image/font"
 )
 
-// Define common methods for all data points
-type DataPointInterface interface {
-	Position() (float32, float32)
-	GetImage() *ebiten.Image
-	IsColliding(snake Snake) bool
+// Size of the placeholder drawn for a missing icon
+const PlaceholderIconSize = 30
+
+// Data points live in the headless simulation
+type DataPointInterface = sim.DataPointInterface
+type DataPoint = sim.DataPoint
+type SpecialDataPoint = sim.SpecialDataPoint
+type PowerUp = sim.PowerUp
+
+// Generate an icon showing the initial of name in a frame
+func PlaceholderIcon(name string) *ebiten.Image {
+	img := ebiten.NewImage(PlaceholderIconSize, PlaceholderIconSize)
+	img.Fill(DarkGreen)
+	img.SubImage(image.Rect(2, 2, PlaceholderIconSize-2, PlaceholderIconSize-2)).(*ebiten.Image).Fill(LighterGreen)
+
+	initial := strings.ToUpper(string([]rune(name + "?")[0]))
+	bounds, _ := font.BoundString(FontM, initial)
+	x := (PlaceholderIconSize-(bounds.Max.X-bounds.Min.X).Round())/2 - bounds.Min.X.Round()
+	y := (PlaceholderIconSize-(bounds.Max.Y-bounds.Min.Y).Round())/2 - bounds.Min.Y.Round()
+	text.Draw(img, initial, FontM, x, y, DarkGreen)
+	return img
 }
 
-// Define a regular data point in the game
-type DataPoint struct {
-	X, Y  float32
-	Image *ebiten.Image
-}
-
-// Define a special data point in the game
-type SpecialDataPoint struct {
-	DataPoint
-	Name  string
-	Slug  string
-	Year  int
-	Text  string
-	Level string
-}
-
-// Return xy coordinates of the DataPoint
-func (d DataPoint) Position() (float32, float32) {
-	return d.X, d.Y
-}
-
-// Get DataPoint or SpecialDataPoint corresponding image
-func (d DataPoint) GetImage() *ebiten.Image {
-	return d.Image
-}
-
-// Check collision with Snake
-func (d DataPoint) IsColliding(snake Snake) bool {
-	headX, headY := snake.Body[0][0], snake.Body[0][1]
-	return headX >= d.X && headX < d.X+1 && headY >= d.Y && headY < d.Y+1
-}
-
-func LoadSpecialDataPoints() ([]SpecialDataPoint, error) {
-	records, err := assets.LoadSpecialDataPointsCSV()
-	if err != nil {
-		return nil, err
+// Get DataPoint, SpecialDataPoint or PowerUp corresponding image
+func (c *Campaign) DataPointImage(dp DataPointInterface) *ebiten.Image {
+	if special, isSpecial := dp.(SpecialDataPoint); isSpecial {
+		return c.SpecialDataPointImage(special)
 	}
-
-	var specialDataPoints []SpecialDataPoint
-	for _, record := range records[1:] {
-		year, _ := strconv.Atoi(record[2])
-		image := assets.MustLoadImage("images/30x30/" + record[1] + ".png")
-		specialDataPoint := SpecialDataPoint{
-			DataPoint: DataPoint{
-				Image: image,
-			},
-			Name:  record[0],
-			Slug:  record[1],
-			Year:  year,
-			Text:  record[3],
-			Level: record[4],
-		}
-		specialDataPoints = append(specialDataPoints, specialDataPoint)
-	}
-
-	return specialDataPoints, nil
-}
-
-func GenerateRandomPosition(snake Snake) [2]float32 {
-	// Calculate the available grid positions within the play area
-	availablePositions := []struct{ x, y int }{}
-	for x := int(PlayAreaX1 / ScreenUnit); x < int(PlayAreaX2/ScreenUnit); x++ {
-		for y := int(PlayAreaY1 / ScreenUnit); y < int(PlayAreaY2/ScreenUnit); y++ {
-			isColliding := false
-			for _, segment := range snake.Body {
-				if int(segment[0]) == x && int(segment[1]) == y {
-					isColliding = true
-					break
-				}
-			}
-			if !isColliding {
-				availablePositions = append(availablePositions, struct{ x, y int }{x, y})
-			}
-		}
+	if powerUp, isPowerUp := dp.(PowerUp); isPowerUp {
+		return EffectIcon(powerUp.Effect)
 	}
-
-	// Randomly select one available position for the data point
-	if len(availablePositions) > 0 {
-		selected := availablePositions[rand.Intn(len(availablePositions))]
-		return [2]float32{float32(selected.x), float32(selected.y)}
-	}
-
-	// Return a default position if no available positions
-	return [2]float32{PlayAreaX1 / ScreenUnit, PlayAreaY1 / ScreenUnit}
-}
-
-// Create a new data point at a valid random position
-func NewDataPoint(snake Snake) DataPoint {
-	position := GenerateRandomPosition(snake)
-	return DataPoint{X: position[0], Y: position[1], Image: assets.DataPointImg}
+	return assets.DataPointImg
 }
 
-// Create a new special data point at a valid random position
-func NewSpecialDataPoint(snake Snake, special SpecialDataPoint) SpecialDataPoint {
-	// Generate a random position for this special data point
-	position := GenerateRandomPosition(snake)
-	special.X = position[0]
-	special.Y = position[1]
-	return special
+// Get the image of a SpecialDataPoint, resolved from its slug
+func (c *Campaign) SpecialDataPointImage(special SpecialDataPoint) *ebiten.Image {
+	return c.icons[special.Slug]
 }
 
 // Place DP image on grid
-func PlaceDataPoint(dp DataPointInterface) (float64, float64, float64) {
-	img := dp.GetImage()
+func (c *Campaign) PlaceDataPoint(dp DataPointInterface) (float64, float64, float64) {
+	img := c.DataPointImage(dp)
 
 	// Calculate dimensions and scaling factor
 	dpWidth := float32(img.Bounds().Dx())
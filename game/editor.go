@@ -0,0 +1,140 @@
+package game
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Return the path of the arena edited from the welcome page when none is given
+func DefaultArenaPath() string {
+	path, err := ConfigPath("arena.txt")
+	if err != nil {
+		return "arena.txt"
+	}
+	return path
+}
+
+// Open the arena editor on the arena saved at ArenaPath, or on an empty one
+func (g *Game) openEditor() {
+	arena, err := sim.LoadArena(g.ArenaPath)
+	g.EditorStatus = ""
+	if errors.Is(err, fs.ErrNotExist) {
+		arena = sim.NewArena()
+	} else if err != nil {
+		log.Printf("Failed to load arena: %v", err)
+		arena = sim.NewArena()
+		g.EditorStatus = g.T("editor.invalid", err)
+	}
+	g.EditorArena = arena
+	g.State = EditorState
+}
+
+// Return the play area cell under the mouse cursor, and false if the cursor is outside of it
+func cursorCell() ([2]float32, bool) {
+	cursorX, cursorY := ebiten.CursorPosition()
+	cell := [2]float32{float32(int(float32(cursorX) / ScreenUnit)), float32(int(float32(cursorY) / ScreenUnit))}
+	inside := cell[0] >= sim.GridX1 && cell[0] < sim.GridX2 && cell[1] >= sim.GridY1 && cell[1] < sim.GridY2
+	return cell, inside
+}
+
+// Paint walls with the left mouse button and erase them with the right one, keeping the snake clear
+func (g *Game) handleEditorInput() {
+	cell, inside := cursorCell()
+	if !inside {
+		return
+	}
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !g.onSpawn(cell) {
+		g.EditorArena.Walls[cell] = true
+	} else if ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) {
+		delete(g.EditorArena.Walls, cell)
+	}
+}
+
+// Check if the snake spawns over cell
+func (g *Game) onSpawn(cell [2]float32) bool {
+	for _, segment := range sim.NewSnake(g.EditorArena.Spawn).Body {
+		if segment == cell {
+			return true
+		}
+	}
+	return false
+}
+
+// Move the head of the spawn to the cell under the cursor, clearing the walls under the snake
+func (g *Game) placeSpawn() {
+	cell, inside := cursorCell()
+	if !inside {
+		return
+	}
+	g.moveSpawn(sim.Spawn{X: cell[0], Y: cell[1], Dir: g.EditorArena.Spawn.Dir})
+}
+
+// Turn the spawn clockwise, clearing the walls under the snake
+func (g *Game) rotateSpawn() {
+	spawn := g.EditorArena.Spawn
+	clockwise := map[Direction]Direction{DirUp: DirRight, DirRight: DirDown, DirDown: DirLeft, DirLeft: DirUp}
+	spawn.Dir = clockwise[spawn.Dir]
+	g.moveSpawn(spawn)
+}
+
+// Set the spawn unless the snake would not fit in the play area, clearing the walls under and right ahead of it
+func (g *Game) moveSpawn(spawn sim.Spawn) {
+	arena := sim.Arena{Walls: sim.Layout{}, Spawn: spawn}
+	if err := arena.Check(); err != nil {
+		g.EditorStatus = g.T("editor.invalid", err)
+		return
+	}
+	g.EditorArena.Spawn = spawn
+	for _, segment := range sim.NewSnake(spawn).Body {
+		delete(g.EditorArena.Walls, segment)
+	}
+	moveX, moveY := spawn.Dir.Vector()
+	for i := 1; i <= sim.WallGraceCells; i++ {
+		delete(g.EditorArena.Walls, [2]float32{spawn.X + float32(i*moveX), spawn.Y + float32(i*moveY)})
+	}
+	g.EditorStatus = ""
+}
+
+// Play the arena right away, back to the editor once the run is over
+func (g *Game) testArena() {
+	if err := g.EditorArena.Check(); err != nil {
+		g.EditorStatus = g.T("editor.invalid", err)
+		return
+	}
+	arena := g.EditorArena
+	g.Arena = &arena
+	g.testing = true
+	g.State = PlayState
+	g.ResetGame()
+}
+
+// Go back to the editor from a test run
+func (g *Game) stopTest() {
+	g.EditorStatus = g.T("editor.tested", g.Score)
+	g.Arena = nil
+	g.testing = false
+	g.State = EditorState
+}
+
+// Write the arena to ArenaPath
+func (g *Game) saveArena() {
+	err := g.EditorArena.Check()
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(g.ArenaPath), 0o755)
+	}
+	if err == nil {
+		err = g.EditorArena.Save(g.ArenaPath)
+	}
+	if err != nil {
+		log.Printf("Failed to save arena: %v", err)
+		g.EditorStatus = g.T("editor.invalid", err)
+		return
+	}
+	g.EditorStatus = g.T("editor.saved", g.ArenaPath)
+}
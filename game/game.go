@@ -3,90 +3,200 @@ package game
 import (
 	"fmt"
 	"log"
-	"os"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/szkjn/snakeopoly-go/i18n"
+	"github.com/szkjn/snakeopoly-go/leaderboard"
+	"github.com/szkjn/snakeopoly-go/sim"
 )
 
+// Adapt the headless simulation to Ebiten input, timing and rendering
 type Game struct {
-	Theme                    ColorTheme
-	Snake                    Snake
-	LastMoveTime             time.Time // Timestamp of the last movement
-	CurrentDir               Direction // Current direction of the snake
-	NextDir                  Direction // Next direction to change to
-	initialSpecialDataPoints []SpecialDataPoint
-	SpecialDataPoints        []SpecialDataPoint
-	CurrentDataPoint         DataPointInterface
-	CurrentSpecialDataPoint  SpecialDataPoint
-	LastSpecialDataPoint     bool
-	UI                       *UI
-	State                    GameState
-	Score                    int8
-	Level                    string
-	Blinking                 bool
-	BlinkTimer               time.Duration
-	BlinkTextTimer           time.Duration
-	SnakeVisible             bool
-	BlinkText                bool
-	TextAnimationTimer       time.Duration
-	CurrentCharIndex         int
-	WelcomeAnimationTimer    time.Duration
-	IsGShape                 bool
-	DebugMode                bool
+	*sim.Simulation
+	Campaigns             []*Campaign // Campaigns offered on the welcome page
+	Campaign              *Campaign   // Campaign being played
+	Language              string      // Language of the texts
+	Languages             []string    // Languages offered on the welcome page
+	Messages              i18n.Catalog
+	Theme                 ColorTheme
+	Scheduler             *Scheduler
+	Tick                  int64         // Number of ticks run so far
+	FixedSeed             bool          // Replay the same seed on every run instead of drawing a new one
+	Recording             *sim.Replay   // Inputs recorded so far, nil unless recording
+	replay                *sim.Replay   // Inputs played back instead of the keyboard
+	replayInput           int           // Index of the next input to play back
+	replaySeed            int           // Index of the next seed to play back
+	SavedGame             *sim.SaveData // Game offered as "Continue" on the welcome page
+	persist               bool          // Save the game in progress on quit
+	quit                  bool
+	HighScores            sim.HighScores
+	NewHighScoreRank      int // Rank of the last entry added to HighScores, -1 if none
+	Codex                 sim.Codex
+	CodexIndex            int          // Entry shown on the codex page
+	Initials              string       // Initials being entered on the initials page
+	SessionStart          time.Time    // Start of the session, naming its quiz results file
+	QuizResults           []QuizResult // Quiz questions answered during the session
+	ArenaPath             string       // File the arena editor loads and saves
+	EditorArena           sim.Arena
+	EditorStatus          string // Outcome of the last editor action
+	testing               bool   // Playing the arena of the editor, back to it once the run is over
+	leaderboard           *leaderboard.Client
+	leaderboardResults    chan leaderboardResult // Outcomes of the submissions in flight
+	LeaderboardStatus     string                 // Outcome of the submission of the current run, shown on the outcome pages
+	runs                  int                    // Runs started or continued so far, telling their submissions apart
+	outcomeState          GameState              // GameOverState or GoalState, shown once initials are entered
+	TimelineTimer         int                    // Ticks spent on the goal page, revealing its timeline
+	TimelineScroll        int                    // First acquisition shown on the timeline
+	ExportStatus          string                 // Outcome of the last timeline export
+	MoveTimer             float64                // Accumulates the speed of the snake every tick, moves on reaching TPS
+	NextDir               Direction              // Next direction to change to
+	UI                    *UI
+	Blinking              bool
+	BlinkTimer            int
+	BlinkTextTimer        int
+	SnakeVisible          bool
+	BlinkText             bool
+	TextAnimationTimer    int
+	CurrentCharIndex      int
+	WelcomeAnimationTimer int
+	IsCampaignShape       bool
+	DebugMode             bool
 }
 
-type GameState int
+type GameState = sim.GameState
 
 const (
-	WelcomeState GameState = iota
-	PlayState
-	GameOverState
-	SpecialState
-	GoalState
-	BlinkState
+	WelcomeState    = sim.WelcomeState
+	PlayState       = sim.PlayState
+	GameOverState   = sim.GameOverState
+	SpecialState    = sim.SpecialState
+	GoalState       = sim.GoalState
+	BlinkState      = sim.BlinkState
+	InitialsState   = sim.InitialsState
+	HighScoresState = sim.HighScoresState
+	CodexState      = sim.CodexState
+	QuizState       = sim.QuizState
+	EditorState     = sim.EditorState
 )
 
-func NewGame() *Game {
-	snake := NewSnake()
-	dataPoint := NewDataPoint(snake)
-	specialDataPoints, err := LoadSpecialDataPoints()
+// Define the options a game is started with
+type Options struct {
+	Seed        int64          // Seed of the data point placement, 0 draws a new one on every run
+	Difficulty  sim.Difficulty // Preset selecting the rules of the game
+	Clock       Clock          // Time source of the tick scheduler, defaults to SystemClock
+	Record      bool           // Record every input into Game.Recording
+	Replay      *sim.Replay    // Play back these inputs instead of reading the keyboard
+	Leaderboard string         // URL of the leaderboard server runs are submitted to, empty for none
+	Content     string         // Campaign dir, or dir of campaign dirs, loaded instead of the embedded ones
+	Campaign    string         // ID of the campaign selected on start, empty for DefaultCampaign
+	Language    string         // Language of the texts, empty for the saved setting or i18n.DefaultLanguage
+	Borderless  bool           // Wrap the snake to the opposite edge instead of ending the run on the border
+	Quiz        bool           // Ask questions about the acquisitions between levels and once the run is over
+	Arena       string         // File the arena editor loads and saves, empty for DefaultArenaPath
+}
+
+func NewGame(opts Options) *Game {
+	campaigns, err := LoadCampaigns(opts.Content)
 	if err != nil {
-		log.Fatalf("Failed to load special data points: %v", err)
+		log.Fatalf("Failed to load campaigns: %v", err)
 	}
-	// Deep copy specialDataPoints to initialSpecialDataPoints
-	initialSpecialDataPoints := make([]SpecialDataPoint, len(specialDataPoints))
-	copy(initialSpecialDataPoints, specialDataPoints)
-
-	initialLevel := specialDataPoints[0].Level
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+	if opts.Campaign == "" {
+		opts.Campaign = DefaultCampaign
+	}
+	// Replays are played back with the campaign and rules they were recorded with
+	if opts.Replay != nil {
+		opts.Campaign = opts.Replay.Campaign
+		opts.Difficulty = opts.Replay.Difficulty
+		opts.Borderless = opts.Replay.Borderless
+		opts.Quiz = opts.Replay.Quiz
+	}
+	campaign := campaigns[campaignIndex(campaigns, opts.Campaign)]
 
 	game := &Game{
-		Theme:                    DayTheme,
-		Snake:                    snake,
-		LastMoveTime:             time.Now(),
-		CurrentDir:               DirRight,
-		NextDir:                  DirRight,
-		initialSpecialDataPoints: initialSpecialDataPoints,
-		SpecialDataPoints:        specialDataPoints,
-		CurrentDataPoint:         dataPoint,
-		LastSpecialDataPoint:     false,
-		UI:                       NewUI(),
-		State:                    WelcomeState,
-		Score:                    0,
-		Level:                    initialLevel,
-		Blinking:                 false,
-		SnakeVisible:             true,
-		BlinkText:                true,
-		CurrentCharIndex:         0,
-		WelcomeAnimationTimer:    0,
-		IsGShape:                 true,
-		DebugMode:                false,
+		Simulation:            sim.NewSimulation(campaign.SpecialDataPoints, campaign.Layouts, campaign.Behaviors, campaign.Regulator, opts.Seed, opts.Difficulty),
+		Campaigns:             campaigns,
+		Campaign:              campaign,
+		Theme:                 campaign.DayTheme,
+		Scheduler:             NewScheduler(opts.Clock, TPS),
+		FixedSeed:             opts.Seed != 0,
+		replay:                opts.Replay,
+		NextDir:               DirRight,
+		UI:                    NewUI(),
+		Blinking:              false,
+		SnakeVisible:          true,
+		BlinkText:             true,
+		CurrentCharIndex:      0,
+		WelcomeAnimationTimer: 0,
+		IsCampaignShape:       true,
+		DebugMode:             false,
+	}
+	game.CampaignID = campaign.ID()
+	game.Speeds = campaign.Speeds
+	game.Borderless = opts.Borderless
+	game.QuizMode = opts.Quiz
+	game.SessionStart = opts.Clock.Now()
+	game.ArenaPath = opts.Arena
+	if game.ArenaPath == "" {
+		game.ArenaPath = DefaultArenaPath()
+	}
+	game.UI.Theme = campaign.DayTheme
+	if opts.Record {
+		game.Recording = &sim.Replay{Campaign: campaign.ID(), Difficulty: opts.Difficulty, Borderless: opts.Borderless, Quiz: opts.Quiz}
 	}
+
+	// Saved games would break the determinism of recordings and replays
+	game.persist = !opts.Record && opts.Replay == nil
+	game.NewHighScoreRank = -1
+	game.Codex = sim.Codex{}
+	if game.persist && opts.Language == "" {
+		settings, err := LoadSettings()
+		if err != nil {
+			log.Printf("Failed to load settings: %v", err)
+		}
+		opts.Language = settings.Language
+	}
+	game.Languages, err = i18n.Languages(Locales())
+	if err != nil {
+		log.Fatalf("Failed to list languages: %v", err)
+	}
+	game.selectLanguage(i18n.DefaultLanguage)
+	if opts.Language != "" {
+		game.selectLanguage(opts.Language)
+	}
+
+	if game.persist {
+		game.SavedGame, err = LoadSave()
+		if err != nil {
+			log.Printf("Failed to load saved game: %v", err)
+		}
+		game.HighScores, err = LoadHighScores()
+		if err != nil {
+			log.Printf("Failed to load high scores: %v", err)
+		}
+		game.Codex, err = LoadCodex()
+		if err != nil {
+			log.Printf("Failed to load codex: %v", err)
+		}
+	}
+
+	// Replayed runs were already submitted when they were played, and the initials of recorded ones are not recorded
+	if opts.Leaderboard != "" && opts.Replay == nil && !opts.Record {
+		game.leaderboard = leaderboard.NewClient(opts.Leaderboard)
+		game.leaderboardResults = make(chan leaderboardResult, 1)
+	}
+
+	game.State = WelcomeState
 	return game
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
+	g.UI.Borderless = g.Borderless
+
 	switch g.State {
 
 	case WelcomeState:
@@ -107,74 +217,115 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	case BlinkState:
 		g.UI.DrawPlayPage(screen, g)
 
+	case InitialsState:
+		g.UI.DrawInitialsPage(screen, g)
+
+	case HighScoresState:
+		g.UI.DrawHighScoresPage(screen, g)
+
+	case CodexState:
+		g.UI.DrawCodexPage(screen, g)
+
+	case QuizState:
+		g.UI.DrawQuizPage(screen, g)
+
+	case EditorState:
+		g.UI.DrawEditorPage(screen, g)
+
 	}
 }
 
 func (g *Game) Update() error {
-	g.handleMacroInput()
+	if ebiten.IsWindowBeingClosed() {
+		g.Quit()
+	}
 
+	if g.State == InitialsState {
+		// Typed letters are initials, not macro keys
+		g.handleInitialsInput()
+	} else if g.replay == nil {
+		g.handleMacroInput()
+
+		if g.State == PlayState {
+			// Handle user input for changing direction
+			g.updateDirection()
+		} else if g.State == CodexState {
+			g.handleCodexInput()
+		} else if g.State == GoalState && g.Recording == nil {
+			g.handleTimelineInput()
+		} else if g.State == EditorState {
+			g.handleEditorInput()
+		}
+	}
+
+	// Pick up the outcome of a leaderboard submission without waiting for it
+	select {
+	case result := <-g.leaderboardResults:
+		// Late outcomes of a previous run are not shown on the pages of the current one
+		if result.run == g.runs {
+			g.LeaderboardStatus = result.status
+		}
+	default:
+	}
+
+	// Run every fixed tick due since the last update
+	for ticks := g.Scheduler.Advance(); ticks > 0; ticks-- {
+		if g.replay != nil {
+			g.playBackInputs()
+		}
+		g.tick()
+		g.Tick++
+	}
+
+	if g.quit {
+		return ebiten.Termination
+	}
+	return nil
+}
+
+// Advance timers and the simulation by one fixed tick
+func (g *Game) tick() {
 	if g.State == WelcomeState {
 		// Animation logic
-		timeElapsed := time.Since(g.LastMoveTime)
-		g.WelcomeAnimationTimer += timeElapsed
+		g.WelcomeAnimationTimer++
 
-		if g.IsGShape && g.WelcomeAnimationTimer >= GShapeTime {
-			g.IsGShape = false
+		if g.IsCampaignShape && g.WelcomeAnimationTimer >= CampaignShapeTime {
+			g.IsCampaignShape = false
 			g.WelcomeAnimationTimer = 0
-		} else if !g.IsGShape && g.WelcomeAnimationTimer >= SixShapeTime {
-			g.IsGShape = true
+		} else if !g.IsCampaignShape && g.WelcomeAnimationTimer >= SixShapeTime {
+			g.IsCampaignShape = true
 			g.WelcomeAnimationTimer = 0
 		}
 
-		// Blinking text logic
-		g.BlinkTextTimer += timeElapsed
-		if g.BlinkTextTimer >= BlinkFreq*2 {
-			g.BlinkText = !g.BlinkText
-			g.BlinkTextTimer = 0
-		}
-
-		g.LastMoveTime = time.Now()
+		g.updateBlinkText()
 
 	} else if g.State == PlayState {
-		// Handle user input for changing direction
-		g.updateDirection()
-		g.UI.Theme = DayTheme
-
-		// Get the current time and calculate the time elapsed since the last movement
-		currentTime := time.Now()
-		elapsedTime := currentTime.Sub(g.LastMoveTime)
-		desiredInterval := time.Second / time.Duration(SnakeSpeed)
+		g.UI.Theme = g.Campaign.DayTheme
 
 		// Check if it's time to move the snake
-		if elapsedTime >= desiredInterval {
-			// Calculate the new head position
-			moveX, moveY := g.CurrentDir.Vector()
-			headX, headY := g.Snake.Body[0][0], g.Snake.Body[0][1]
-			nextHeadX := headX + float32(moveX)
-			nextHeadY := headY + float32(moveY)
-
-			// Check collision with play area border
-			if nextHeadX < PlayAreaX1/ScreenUnit || nextHeadX >= PlayAreaX2/ScreenUnit || nextHeadY < PlayAreaY1/ScreenUnit || nextHeadY >= PlayAreaY2/ScreenUnit {
-				g.State = GameOverState
-				// Check collision with itself
-			} else if g.Snake.CollidesWithItself(nextHeadX, nextHeadY) {
-				g.State = GameOverState
-			} else {
-				// Move the snake and handle collision with the current data point
-				g.handleSnakeMovementAndCollision(nextHeadX, nextHeadY)
-
-				// Update the last movement time
-				g.LastMoveTime = currentTime
+		g.MoveTimer += g.Speed()
+		if g.MoveTimer >= float64(TPS) {
+			g.MoveTimer -= float64(TPS)
+			// Advance the simulation by one cell
+			events := g.Step(g.NextDir)
+			// Test runs of the arena editor leave the codex alone
+			if events.Special && !g.testing {
+				g.unlockSpecial(g.CurrentSpecialDataPoint)
+			}
+			// Test runs go back to the editor, runs in quiz mode check the high score once the final quiz is over
+			if g.testing && (events.GameOver || events.Goal) {
+				g.stopTest()
+			} else if (events.GameOver || events.Goal) && g.State != QuizState {
+				g.checkHighScore()
 			}
 		}
 
 	} else if g.State == BlinkState {
-		g.UI.Theme = DayTheme
+		g.UI.Theme = g.Campaign.DayTheme
 
-		if time.Since(g.LastMoveTime) >= BlinkFreq {
+		g.BlinkTimer++
+		if g.BlinkTimer%BlinkFreq == 0 {
 			g.SnakeVisible = !g.SnakeVisible
-			g.BlinkTimer += time.Since(g.LastMoveTime)
-			g.LastMoveTime = time.Now()
 		}
 		// Check if blinking duration has elapsed
 		if g.BlinkTimer >= TotalBlinkDuration {
@@ -184,54 +335,140 @@ func (g *Game) Update() error {
 		}
 
 	} else if g.State == SpecialState {
-		g.UI.Theme = ApocalypseTheme
-		g.TextAnimationTimer += time.Since(g.LastMoveTime)
+		g.UI.Theme = g.Campaign.ApocalypseTheme
+		g.TextAnimationTimer++
 		if g.TextAnimationTimer >= TextAnimationSpeed {
 			g.TextAnimationTimer -= TextAnimationSpeed
 			g.CurrentCharIndex++
 		}
-		// Toggle blinking text
-		if time.Since(g.LastMoveTime) >= BlinkFreq*2 {
-			g.BlinkText = !g.BlinkText
-			g.LastMoveTime = time.Now()
-		}
+		g.updateBlinkText()
 
-	} else if g.State == GameOverState || g.State == GoalState {
-		g.UI.Theme = ApocalypseTheme
-		// Toggle blinking text
-		if time.Since(g.LastMoveTime) >= BlinkFreq*2 {
-			g.BlinkText = !g.BlinkText
-			g.LastMoveTime = time.Now()
+	} else if g.State == GameOverState || g.State == GoalState || g.State == InitialsState || g.State == HighScoresState || g.State == CodexState || g.State == QuizState {
+		g.UI.Theme = g.Campaign.ApocalypseTheme
+		g.updateBlinkText()
+		if g.State == GoalState {
+			g.updateTimeline()
 		}
+
+	} else if g.State == EditorState {
+		g.UI.Theme = g.Campaign.DayTheme
+		g.updateBlinkText()
 	}
+}
 
-	return nil
+// Toggle blinking text every other BlinkFreq
+func (g *Game) updateBlinkText() {
+	g.BlinkTextTimer++
+	if g.BlinkTextTimer >= BlinkFreq*2 {
+		g.BlinkText = !g.BlinkText
+		g.BlinkTextTimer = 0
+	}
 }
 
 func (g *Game) ResetGame() {
-	g.Snake = NewSnake()
-	g.LastMoveTime = time.Now()
-	g.CurrentDir = DirRight
-	g.NextDir = DirRight
-	g.Score = 0
-	g.State = PlayState
-	g.CurrentDataPoint = NewDataPoint(g.Snake)
-
-	// Reset specialDataPoints to their initial state
-	g.SpecialDataPoints = make([]SpecialDataPoint, len(g.initialSpecialDataPoints))
-	copy(g.SpecialDataPoints, g.initialSpecialDataPoints)
-	g.Level = g.initialSpecialDataPoints[0].Level
+	// Pick the seed of the run, and print it so the run can be replayed
+	g.Seed = g.nextSeed()
+	fmt.Printf("Seed: %d\n", g.Seed)
+	if g.Recording != nil {
+		g.Recording.Seeds = append(g.Recording.Seeds, g.Seed)
+	}
+
+	g.Simulation.Reset()
+	g.runs++
+	g.NewHighScoreRank = -1
+	g.LeaderboardStatus = ""
+	g.TimelineTimer = 0
+	g.TimelineScroll = 0
+	g.ExportStatus = ""
+	g.MoveTimer = 0
+	g.NextDir = g.CurrentDir
 }
 
 func (g *Game) ResumeGame() {
+	// Ask the level quiz first if the acquisition changed the level
+	g.Resume()
+	if g.State == QuizState {
+		return
+	}
 	g.State = BlinkState
-	g.LastMoveTime = time.Now()
 	g.Blinking = true
 	g.BlinkTimer = 0
 	g.SnakeVisible = false
 	g.CurrentCharIndex = 0
 }
 
+// Use the replayed seed, the fixed one, or draw a new one from the clock
+func (g *Game) nextSeed() int64 {
+	if g.replay != nil && g.replaySeed < len(g.replay.Seeds) {
+		g.replaySeed++
+		return g.replay.Seeds[g.replaySeed-1]
+	}
+	if g.FixedSeed {
+		return g.Seed
+	}
+	return g.Scheduler.Clock.Now().UnixNano()
+}
+
+// Restore the saved game, in the campaign it was played in, and resume it
+func (g *Game) ContinueGame() {
+	if g.SavedGame.Campaign != g.Campaign.ID() {
+		g.selectCampaign(campaignIndex(g.Campaigns, g.SavedGame.Campaign))
+	}
+	if err := g.Restore(*g.SavedGame); err != nil {
+		log.Printf("Failed to restore saved game: %v", err)
+		g.SavedGame = nil
+		return
+	}
+	g.SavedGame = nil
+	g.runs++
+	g.LeaderboardStatus = ""
+	g.MoveTimer = 0
+	g.NextDir = g.CurrentDir
+
+	// Show the special page or the quiz again, or blink the snake before moving
+	if g.State == SpecialState || g.State == QuizState {
+		g.CurrentCharIndex = 0
+	} else {
+		g.ResumeGame()
+	}
+}
+
+// Switch to the campaign at index i, starting its simulation over
+func (g *Game) selectCampaign(i int) {
+	g.Campaign = g.Campaigns[i]
+	borderless, quizMode := g.Borderless, g.QuizMode
+	g.Simulation = sim.NewSimulation(g.Campaign.SpecialDataPoints, g.Campaign.Layouts, g.Campaign.Behaviors, g.Campaign.Regulator, g.Seed, g.Difficulty)
+	g.Speeds = g.Campaign.Speeds
+	g.CampaignID = g.Campaign.ID()
+	g.Borderless, g.QuizMode = borderless, quizMode
+	g.State = WelcomeState
+}
+
+// Save the game in progress and stop the game at the end of the current update
+func (g *Game) Quit() {
+	// Test runs of the arena editor are not saved
+	if g.persist && !g.testing {
+		var err error
+		switch g.State {
+		case PlayState, BlinkState, SpecialState:
+			err = WriteSave(g.Snapshot())
+		case GameOverState, GoalState, InitialsState:
+			err = DeleteSave()
+		case QuizState:
+			// Keep the run only if the quiz is asked between levels
+			if g.Quiz.Outcome == PlayState {
+				err = WriteSave(g.Snapshot())
+			} else {
+				err = DeleteSave()
+			}
+		}
+		if err != nil {
+			log.Printf("Failed to save game: %v", err)
+		}
+	}
+	g.quit = true
+}
+
 func (g *Game) handleMacroInput() {
 
 	// Get the input characters
@@ -239,119 +476,179 @@ func (g *Game) handleMacroInput() {
 
 	// Detect if a key is pressed
 	if len(inputChars) == 1 {
-		// If "d" is pressed
-		if inputChars[0] == 100 {
-			g.DebugMode = !g.DebugMode
-			fmt.Println("debugmode")
+		g.record(sim.InputKey, int32(inputChars[0]))
+		g.handleMacroKey(inputChars[0])
+	}
+
+}
+
+func (g *Game) handleMacroKey(key rune) {
+	// If "d" is pressed
+	if key == 100 {
+		g.DebugMode = !g.DebugMode
+		fmt.Println("debugmode")
+	}
+	// If "1" is pressed
+	// if key == 49 {
+	// 	g.UI.ToggleTheme(DayTheme)
+	// } else if key == 50 {
+	// 	g.UI.ToggleTheme(NightTheme)
+	// }
+
+	if g.State == WelcomeState || g.State == GameOverState || g.State == GoalState {
+
+		// If "P" is pressed
+		if key == 112 {
+			g.State = PlayState
+			g.ResetGame()
+			// If "C" is pressed
+		} else if key == 99 && g.State == WelcomeState && g.SavedGame != nil {
+			g.ContinueGame()
+			// If "N" is pressed
+		} else if key == 110 && g.State == WelcomeState {
+			g.selectCampaign((campaignIndex(g.Campaigns, g.Campaign.ID()) + 1) % len(g.Campaigns))
+			// If "L" is pressed
+		} else if key == 108 && g.State == WelcomeState {
+			g.nextLanguage()
+			// If "E" is pressed
+		} else if key == 101 && g.State == WelcomeState && !g.replayable() {
+			g.openEditor()
+			// If "W" is pressed
+		} else if key == 119 && g.State == WelcomeState {
+			g.Borderless = !g.Borderless
+			// If "H" is pressed
+		} else if key == 104 && g.State == WelcomeState {
+			g.NewHighScoreRank = -1
+			g.State = HighScoresState
+			// If "A" is pressed
+		} else if key == 97 && g.State == WelcomeState && !g.replayable() {
+			g.openCodex()
+			// If "E" is pressed
+		} else if key == 101 && g.State == GoalState {
+			g.exportTimeline()
+			// If "Q" is pressed
+		} else if key == 113 {
+			g.Quit()
+		}
+
+	} else if g.State == HighScoresState || g.State == CodexState {
+
+		// If "B" is pressed
+		if key == 98 {
+			g.State = WelcomeState
+			// If "Q" is pressed
+		} else if key == 113 {
+			g.Quit()
 		}
-		// If "1" is pressed
-		// if inputChars[0] == 49 {
-		// 	g.UI.ToggleTheme(DayTheme)
-		// } else if inputChars[0] == 50 {
-		// 	g.UI.ToggleTheme(NightTheme)
-		// }
-
-		if g.State == WelcomeState || g.State == GameOverState || g.State == GoalState {
-
-			// If "P" is pressed
-			if inputChars[0] == 112 {
-				g.State = PlayState
-				g.ResetGame()
-				// If "Q" is pressed
-			} else if inputChars[0] == 113 {
-				quitGame()
-			}
 
-		} else if g.State == SpecialState {
+	} else if g.State == SpecialState {
+
+		// If "R" is pressed
+		if key == 114 {
+			g.ResumeGame()
+			// If "Q" is pressed
+		} else if key == 113 {
+			g.Quit()
+		}
+
+	} else if g.State == EditorState {
 
+		// If "P" is pressed
+		if key == 112 {
+			g.placeSpawn()
 			// If "R" is pressed
-			if inputChars[0] == 114 {
-				g.ResumeGame()
-				// If "Q" is pressed
-			} else if inputChars[0] == 113 {
-				quitGame()
-			}
+		} else if key == 114 {
+			g.rotateSpawn()
+			// If "T" is pressed
+		} else if key == 116 {
+			g.testArena()
+			// If "S" is pressed
+		} else if key == 115 {
+			g.saveArena()
+			// If "C" is pressed
+		} else if key == 99 {
+			g.EditorArena.Walls = sim.Layout{}
+			// If "B" is pressed
+		} else if key == 98 {
+			g.State = WelcomeState
+			// If "Q" is pressed
+		} else if key == 113 {
+			g.Quit()
 		}
-	}
 
+	} else if g.State == PlayState && g.testing {
+
+		// If "B" is pressed
+		if key == 98 {
+			g.stopTest()
+		}
+
+	} else if g.State == QuizState {
+
+		// If "1" to "4" is pressed
+		if key >= 49 && key < 49+rune(sim.QuizChoices) {
+			g.answerQuiz(int(key - 49))
+			// If "R" is pressed
+		} else if key == 114 {
+			g.nextQuestion()
+			// If "Q" is pressed
+		} else if key == 113 {
+			g.Quit()
+		}
+	}
 }
 
 func (g *Game) updateDirection() {
+	nextDir := g.NextDir
 	if inpututil.IsKeyJustPressed(ebiten.KeyUp) && g.CurrentDir != DirDown {
-		g.NextDir = DirUp
+		nextDir = DirUp
 	}
 	if inpututil.IsKeyJustPressed(ebiten.KeyDown) && g.CurrentDir != DirUp {
-		g.NextDir = DirDown
+		nextDir = DirDown
 	}
 	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) && g.CurrentDir != DirRight {
-		g.NextDir = DirLeft
+		nextDir = DirLeft
 	}
 	if inpututil.IsKeyJustPressed(ebiten.KeyRight) && g.CurrentDir != DirLeft {
-		g.NextDir = DirRight
+		nextDir = DirRight
 	}
 
-	// Update the current direction based on the next direction
-	if g.CurrentDir != g.NextDir && !g.CurrentDir.IsOpposite(g.NextDir) {
-		g.CurrentDir = g.NextDir
+	if nextDir != g.NextDir {
+		g.record(sim.InputDirection, int32(nextDir))
+		g.NextDir = nextDir
 	}
 }
 
-func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return int(ScreenWidth), int(ScreenHeight)
+// Check if the session is recorded or played back, leaving out the screens whose input is not recorded
+func (g *Game) replayable() bool {
+	return g.Recording != nil || g.replay != nil
 }
 
-func quitGame() {
-	os.Exit(0)
+// Record an input applied before the next tick
+func (g *Game) record(kind sim.InputKind, value int32) {
+	if g.Recording != nil {
+		g.Recording.Record(g.Tick, kind, value)
+	}
 }
 
-func (g *Game) generateDataPoint() {
-	if len(g.SpecialDataPoints) > 0 {
-		if g.Score%SpecialDataPointsRate == 0 {
-			// Use the first special data point
-			g.CurrentDataPoint = NewSpecialDataPoint(g.Snake, g.SpecialDataPoints[0])
-			g.SpecialDataPoints = g.SpecialDataPoints[1:]
-
-			// Check if special data points have run out
-			if len(g.SpecialDataPoints) == 0 {
-				g.LastSpecialDataPoint = true
+// Apply every replayed input due before the next tick
+func (g *Game) playBackInputs() {
+	for g.replayInput < len(g.replay.Inputs) && g.replay.Inputs[g.replayInput].Tick <= g.Tick {
+		input := g.replay.Inputs[g.replayInput]
+		g.replayInput++
+
+		switch input.Kind {
+		case sim.InputDirection:
+			g.NextDir = Direction(input.Value)
+		case sim.InputKey:
+			// Keep the window open once the replay is over
+			if input.Value != 113 {
+				g.handleMacroKey(rune(input.Value))
 			}
-		} else {
-			// Generate a regular data point
-			g.CurrentDataPoint = NewDataPoint(g.Snake)
 		}
-	} else {
-		// Generate a regular data point
-		g.CurrentDataPoint = NewDataPoint(g.Snake)
 	}
 }
 
-func (g *Game) handleSnakeMovementAndCollision(nextHeadX, nextHeadY float32) {
-	if g.CurrentDataPoint != nil && g.CurrentDataPoint.IsColliding(g.Snake) {
-		// Collision detected, increase score
-		g.Score++
-
-		// Check if the current data point is special
-		if specialDP, isSpecial := g.CurrentDataPoint.(SpecialDataPoint); isSpecial {
-			g.State = SpecialState // Trigger special state on collision with special data point
-			g.CurrentSpecialDataPoint = specialDP
-			g.Level = specialDP.Level
-
-			// Check if this is the last special data point
-			if g.LastSpecialDataPoint {
-				g.State = GoalState
-			}
-		}
-
-		// Generate a new data point after handling the current collision
-		g.generateDataPoint()
-
-		// Add the new head position and handle snake growth
-		g.Snake.Body = append([][2]float32{{nextHeadX, nextHeadY}}, g.Snake.Body...)
-	} else {
-		// Move the snake without growing
-		g.Snake.Body = append([][2]float32{{nextHeadX, nextHeadY}}, g.Snake.Body...)
-		if len(g.Snake.Body) > int(InitialSnakeLength) {
-			g.Snake.Body = g.Snake.Body[:len(g.Snake.Body)-1]
-		}
-	}
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return int(ScreenWidth), int(ScreenHeight)
 }
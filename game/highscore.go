@@ -0,0 +1,107 @@
+package game
+
+import (
+	"log"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/szkjn/snakeopoly-go/i18n"
+	"github.com/szkjn/snakeopoly-go/leaderboard"
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Maximum number of characters of the initials
+const MaxInitials = 3
+
+// Read the local high score table, empty if there is none yet
+func LoadHighScores() (sim.HighScores, error) {
+	var highScores sim.HighScores
+	if _, err := readConfigJSON("highscores.json", &highScores); err != nil {
+		return nil, err
+	}
+	return highScores, nil
+}
+
+// Write the local high score table
+func WriteHighScores(highScores sim.HighScores) error {
+	return writeConfigJSON("highscores.json", highScores)
+}
+
+// Ask for initials if the run that just ended enters the high score table or goes to the leaderboard
+func (g *Game) checkHighScore() {
+	qualifies := g.persist && g.HighScores.Qualifies(g.Score)
+	if !qualifies && (g.leaderboard == nil || g.Score <= 0) {
+		return
+	}
+	g.outcomeState = g.State
+	g.Initials = ""
+	g.State = InitialsState
+}
+
+func (g *Game) handleInitialsInput() {
+	for _, char := range ebiten.AppendInputChars(nil) {
+		char = unicode.ToUpper(char)
+		if len(g.Initials) < MaxInitials && char < unicode.MaxASCII && (unicode.IsLetter(char) || unicode.IsDigit(char)) {
+			g.Initials += string(char)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(g.Initials) > 0 {
+		g.Initials = g.Initials[:len(g.Initials)-1]
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && len(g.Initials) > 0 {
+		g.submitHighScore()
+	}
+}
+
+// Add the run to the high score table and the leaderboard, and show its outcome page
+func (g *Game) submitHighScore() {
+	entry := sim.HighScore{
+		Initials:     g.Initials,
+		Campaign:     g.CampaignID,
+		Score:        g.Score,
+		Level:        g.Level,
+		Acquisitions: g.Acquisitions,
+		Seed:         g.Seed,
+		Date:         g.Scheduler.Clock.Now(),
+	}
+	if g.persist && g.HighScores.Qualifies(g.Score) {
+		g.HighScores, g.NewHighScoreRank = g.HighScores.Insert(entry)
+		if err := WriteHighScores(g.HighScores); err != nil {
+			log.Printf("Failed to save high scores: %v", err)
+		}
+	}
+	if g.leaderboard != nil {
+		g.submitToLeaderboard()
+	}
+	g.State = g.outcomeState
+}
+
+// Define the outcome of a submission, for the run it was sent for
+type leaderboardResult struct {
+	run    int
+	status string
+}
+
+// Send the run to the leaderboard in the background, reporting to leaderboardResults
+func (g *Game) submitToLeaderboard() {
+	submission := leaderboard.Submission{
+		Initials:     g.Initials,
+		Score:        g.Score,
+		Level:        g.Level,
+		Acquisitions: g.Acquisitions,
+		Replay:       g.Log(),
+	}
+	g.LeaderboardStatus = g.T("leaderboard.submitting")
+
+	go func(client *leaderboard.Client, messages i18n.Catalog, run int, results chan<- leaderboardResult) {
+		result, err := client.Submit(submission)
+		if err != nil {
+			log.Printf("Failed to submit to the leaderboard: %v", err)
+			results <- leaderboardResult{run: run, status: messages.T("leaderboard.unavailable")}
+			return
+		}
+		results <- leaderboardResult{run: run, status: messages.T("leaderboard.rank", result.Rank+1, result.SeedRank+1)}
+	}(g.leaderboard, g.Messages, g.runs, g.leaderboardResults)
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Size of a pixel of an effect shape in its icon
+const EffectIconPixelSize = PlaceholderIconSize / 6
+
+// Pixel shapes of the power-up effects, by effect slug
+var EffectShapes = map[string][][]int{
+	"lobbyist":              LobbyistShape,
+	"data-center":           DataCenterShape,
+	"privacy-policy-update": PrivacyPolicyShape,
+}
+
+var effectIcons = map[string]*ebiten.Image{}
+
+// Return the icon of an effect, drawn from its shape or a placeholder showing its initial
+func EffectIcon(slug string) *ebiten.Image {
+	if icon, found := effectIcons[slug]; found {
+		return icon
+	}
+
+	shape, found := EffectShapes[slug]
+	if !found {
+		effectIcons[slug] = PlaceholderIcon(slug)
+		return effectIcons[slug]
+	}
+	icon := ebiten.NewImage(PlaceholderIconSize, PlaceholderIconSize)
+	for i, row := range shape {
+		for j, pixel := range row {
+			if pixel == 1 {
+				rect := image.Rect(j*EffectIconPixelSize, i*EffectIconPixelSize, (j+1)*EffectIconPixelSize, (i+1)*EffectIconPixelSize)
+				icon.SubImage(rect).(*ebiten.Image).Fill(DarkGreen)
+			}
+		}
+	}
+	effectIcons[slug] = icon
+	return icon
+}
+
+// Return the name of an effect in the current language, or its slug if it has none
+func (g *Game) EffectName(slug string) string {
+	key := "effect." + slug
+	if name := g.T(key); name != key {
+		return name
+	}
+	return slug
+}
+
+// Return the moves left before the effect wears off
+func (g *Game) EffectMovesLeft(active sim.ActiveEffect) int {
+	return active.Until - g.Steps
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Define a quiz question as answered during a session
+type QuizResult struct {
+	Date     time.Time
+	Campaign string
+	Seed     int64
+	Kind     string
+	Subject  string // Slug of the special data point the question was about
+	Choice   string
+	Answer   string
+	Correct  bool
+}
+
+// Count the questions answered right among results
+func CorrectAnswers(results []QuizResult) int {
+	correct := 0
+	for _, result := range results {
+		if result.Correct {
+			correct++
+		}
+	}
+	return correct
+}
+
+// Write the quiz results of the session, one file per session
+func WriteQuizResults(start time.Time, results []QuizResult) error {
+	return writeConfigJSON("quiz/"+start.Format("20060102-150405")+".json", results)
+}
+
+// Answer the current quiz question with the choice at index choice, recording the result of the session unless test-playing an arena
+func (g *Game) answerQuiz(choice int) {
+	question := g.Quiz.Question()
+	if g.Quiz.Answered || choice >= len(question.Choices) {
+		return
+	}
+	events := g.AnswerQuiz(choice)
+	if g.testing {
+		return
+	}
+
+	g.QuizResults = append(g.QuizResults, QuizResult{
+		Date:     g.Scheduler.Clock.Now(),
+		Campaign: g.CampaignID,
+		Seed:     g.Seed,
+		Kind:     question.Kind.String(),
+		Subject:  question.Subject.Slug,
+		Choice:   question.Choices[choice],
+		Answer:   question.Choices[question.Answer],
+		Correct:  events.Correct,
+	})
+	if g.persist {
+		if err := WriteQuizResults(g.SessionStart, g.QuizResults); err != nil {
+			log.Printf("Failed to save quiz results: %v", err)
+		}
+	}
+}
+
+// Move on to the next question, back to the run or to its outcome once the quiz is over
+func (g *Game) nextQuestion() {
+	g.NextQuestion()
+	if g.State == PlayState {
+		g.ResumeGame()
+	} else if g.State == GameOverState || g.State == GoalState {
+		g.checkHighScore()
+	}
+}
+
+// Phrase the current quiz question
+func (g *Game) QuestionDisplay(question sim.Question) string {
+	switch question.Kind {
+	case sim.QuestionSummary:
+		return g.T("quiz.summary", question.Subject.LocalizedSummary(g.Language))
+	case sim.QuestionPrice:
+		return g.T("quiz.price", question.Subject.Price)
+	}
+	return g.T("quiz.year", question.Subject.Name)
+}
+
+// Label the choice at index i with the key selecting it
+func ChoiceDisplay(i int, choice string) string {
+	return strconv.Itoa(i+1) + ". " + choice
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Return the path of a file in the snakeopoly dir of the user config dir
+func ConfigPath(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "snakeopoly", name), nil
+}
+
+// Decode the JSON config file into v, returning false if the file does not exist
+func readConfigJSON(name string, v any) (bool, error) {
+	path, err := ConfigPath(name)
+	if err != nil {
+		return false, err
+	}
+
+	fileData, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(fileData, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Encode v into the JSON config file, creating its dir if needed
+func writeConfigJSON(name string, v any) error {
+	path, err := ConfigPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	fileData, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, fileData, 0o644)
+}
+
+// Read the saved game, returning nil if there is none
+func LoadSave() (*sim.SaveData, error) {
+	var data sim.SaveData
+	found, err := readConfigJSON("save.json", &data)
+	if !found || err != nil {
+		return nil, err
+	}
+	if data.Version != sim.SaveVersion {
+		return nil, fmt.Errorf("save: unsupported version %d", data.Version)
+	}
+	return &data, nil
+}
+
+// Write the game in progress to the save file
+func WriteSave(data sim.SaveData) error {
+	return writeConfigJSON("save.json", data)
+}
+
+// Remove the save file once its game is over
+func DeleteSave() error {
+	path, err := ConfigPath("save.json")
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
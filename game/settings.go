@@ -0,0 +1,77 @@
+package game
+
+import (
+	"io/fs"
+	"log"
+
+	"github.com/szkjn/snakeopoly-go/assets"
+	"github.com/szkjn/snakeopoly-go/i18n"
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Define the preferences kept between sessions
+type Settings struct {
+	Language string
+}
+
+// Read the settings, zero if there are none yet
+func LoadSettings() (Settings, error) {
+	var settings Settings
+	_, err := readConfigJSON("settings.json", &settings)
+	return settings, err
+}
+
+// Write the settings
+func WriteSettings(settings Settings) error {
+	return writeConfigJSON("settings.json", settings)
+}
+
+// Return the embedded message catalogs
+func Locales() fs.FS {
+	locales, err := fs.Sub(assets.FS(), "locales")
+	if err != nil {
+		panic(err)
+	}
+	return locales
+}
+
+// Switch the messages and campaign texts to lang, keeping the current ones if it has no catalog
+func (g *Game) selectLanguage(lang string) {
+	messages, err := i18n.Load(Locales(), lang)
+	if err != nil {
+		log.Printf("Failed to load language %q: %v", lang, err)
+		return
+	}
+	g.Language = lang
+	g.Messages = messages
+}
+
+// Switch to the next language and remember it for the next sessions
+func (g *Game) nextLanguage() {
+	i := 0
+	for i < len(g.Languages) && g.Languages[i] != g.Language {
+		i++
+	}
+	g.selectLanguage(g.Languages[(i+1)%len(g.Languages)])
+
+	if g.persist {
+		if err := WriteSettings(Settings{Language: g.Language}); err != nil {
+			log.Printf("Failed to save settings: %v", err)
+		}
+	}
+}
+
+// Return the message of key in the current language, formatted with args
+func (g *Game) T(key string, args ...any) string {
+	return g.Messages.T(key, args...)
+}
+
+// Return a level name in the current language
+func (g *Game) LocalizedLevel(level string) string {
+	for _, campaign := range g.Campaigns {
+		if localized := sim.LocalizedLevel(campaign.SpecialDataPoints, level, g.Language); localized != level {
+			return localized
+		}
+	}
+	return level
+}
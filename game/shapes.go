@@ -175,6 +175,46 @@ var FireShape = [][]int{
 	{0, 0, 1, 1, 1, 1, 1, 1, 0, 1, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 1, 1, 0, 1, 0, 0, 0, 0, 0, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 1, 0, 1, 0, 0, 0},
 }
 
+// Pixelated regulator shape, filling one grid cell
+var RegulatorShape = [][]int{
+	{0, 0, 1, 1, 0, 0},
+	{1, 1, 1, 1, 1, 1},
+	{1, 0, 1, 1, 0, 1},
+	{1, 0, 1, 1, 0, 1},
+	{0, 0, 1, 1, 0, 0},
+	{0, 1, 1, 1, 1, 0},
+}
+
+// Pixelated lobbyist briefcase shape
+var LobbyistShape = [][]int{
+	{0, 1, 1, 1, 1, 0},
+	{0, 1, 0, 0, 1, 0},
+	{1, 1, 1, 1, 1, 1},
+	{1, 1, 0, 0, 1, 1},
+	{1, 1, 1, 1, 1, 1},
+	{1, 1, 1, 1, 1, 1},
+}
+
+// Pixelated data center rack shape
+var DataCenterShape = [][]int{
+	{1, 1, 1, 1, 1, 1},
+	{1, 0, 0, 0, 1, 1},
+	{1, 1, 1, 1, 1, 1},
+	{1, 0, 0, 0, 1, 1},
+	{1, 1, 1, 1, 1, 1},
+	{0, 1, 0, 0, 1, 0},
+}
+
+// Pixelated privacy policy document shape
+var PrivacyPolicyShape = [][]int{
+	{1, 1, 1, 1, 0, 0},
+	{1, 0, 0, 1, 1, 0},
+	{1, 0, 0, 0, 1, 1},
+	{1, 0, 1, 1, 0, 1},
+	{1, 0, 0, 0, 0, 1},
+	{1, 1, 1, 1, 1, 1},
+}
+
 // var EvilShape = [][]int{
 // 	{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
 // 	{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Return the tick of the run, not counting pauses, reached after seconds of play
+func PlayTicks(seconds float64) int64 {
+	return int64(math.Round(seconds * float64(TPS)))
+}
+
+// Format ticks as minutes and seconds
+func FormatPlayTime(ticks int64) string {
+	seconds := ticks / int64(TPS)
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// Return the number of acquisitions revealed so far by the timeline animation
+func (g *Game) TimelineRevealed() int {
+	revealed := g.TimelineTimer / TimelineRevealTime
+	if entries := len(g.Timeline()); revealed > entries {
+		revealed = entries
+	}
+	return revealed
+}
+
+// Reveal the next acquisition of the timeline, scrolling down to keep it in sight
+func (g *Game) updateTimeline() {
+	g.TimelineTimer++
+	if revealed := g.TimelineRevealed(); revealed > g.TimelineScroll+TimelineRows {
+		g.TimelineScroll = revealed - TimelineRows
+	}
+}
+
+// Scroll the timeline with the arrow keys, revealing every acquisition at once
+func (g *Game) handleTimelineInput() {
+	entries := len(g.Timeline())
+	maxScroll := entries - TimelineRows
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	scroll := g.TimelineScroll
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) && scroll < maxScroll {
+		scroll++
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) && scroll > 0 {
+		scroll--
+	}
+	if scroll != g.TimelineScroll {
+		g.TimelineScroll = scroll
+		g.TimelineTimer = entries * TimelineRevealTime
+	}
+}
+
+// Export the whole timeline of the run as a PNG in the empires dir of the user config dir
+func (g *Game) exportTimeline() {
+	name := fmt.Sprintf("%s-%s.png", g.CampaignID, g.Scheduler.Clock.Now().Format("20060102-150405"))
+	path, err := ConfigPath(filepath.Join("empires", name))
+	if err == nil {
+		err = writePNG(path, g.UI.DrawTimelineImage(g))
+	}
+	if err != nil {
+		log.Printf("Failed to export timeline: %v", err)
+		g.ExportStatus = g.T("timeline.failed")
+		return
+	}
+	g.ExportStatus = g.T("timeline.exported", path)
+}
+
+// Encode img as a PNG file at path, creating its dir if needed
+func writePNG(path string, img *ebiten.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+	img.ReadPixels(rgba.Pix)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, rgba); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
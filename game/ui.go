@@ -5,19 +5,23 @@ import (
 	"image/color"
 	"math"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/szkjn/snakeopoly-go/i18n"
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/typeset"
 	"golang.org/x/image/font"
 )
 
 // Define a UI struct to manage UI elements
 type UI struct {
-	score    int8
-	gameOver bool
-	Theme    ColorTheme
+	gameOver   bool
+	Theme      ColorTheme
+	Borderless bool // Draw the play area outline dashed, the snake wrapping around its edges
 }
 
 // Define color themes
@@ -47,7 +51,7 @@ var ApocalypseTheme = ColorTheme{
 
 // Initialize and return a new UI instance
 func NewUI() *UI {
-	return &UI{score: 0, gameOver: false, Theme: DayTheme}
+	return &UI{gameOver: false, Theme: DayTheme}
 }
 
 // Toggles between color themes
@@ -77,24 +81,56 @@ func (ui *UI) DrawGrid(screen *ebiten.Image) {
 	}
 }
 
-// Draw Play Area borders
+// Draw Play Area borders, dashed in borderless mode
 func (ui *UI) DrawPlayArea(screen *ebiten.Image) {
-	vector.StrokeRect(screen, PlayAreaX1, PlayAreaY1, PlayAreaWidth, PlayAreaHeight, 2, ui.Theme.DrawElement, false)
+	if !ui.Borderless {
+		vector.StrokeRect(screen, PlayAreaX1, PlayAreaY1, PlayAreaWidth, PlayAreaHeight, 2, ui.Theme.DrawElement, false)
+		return
+	}
+	dash := ScreenUnit / 2
+	for x := PlayAreaX1; x < PlayAreaX2; x += 2 * dash {
+		vector.StrokeLine(screen, x, PlayAreaY1, x+dash, PlayAreaY1, 2, ui.Theme.DrawElement, false)
+		vector.StrokeLine(screen, x, PlayAreaY2, x+dash, PlayAreaY2, 2, ui.Theme.DrawElement, false)
+	}
+	for y := PlayAreaY1; y < PlayAreaY2; y += 2 * dash {
+		vector.StrokeLine(screen, PlayAreaX1, y, PlayAreaX1, y+dash, 2, ui.Theme.DrawElement, false)
+		vector.StrokeLine(screen, PlayAreaX2, y, PlayAreaX2, y+dash, 2, ui.Theme.DrawElement, false)
+	}
 }
 
 // Draw Welcome Page
 func (ui *UI) DrawWelcomePage(screen *ebiten.Image, g *Game) {
 	ui.DrawBaseElements(screen, g.DebugMode)
 
-	ui.DrawText(screen, "center", "Welcome to the Google's Snakeopoly!", FontL, 4)
-	ui.DrawText(screen, "center", "Slither your way", FontL, 6)
-	ui.DrawText(screen, "center", "to Surveillance Sovereignty!", FontL, 7.5)
+	texts := g.Campaign.CopyFor(g.Language)
+	walls := g.T("walls.solid")
+	if g.Borderless {
+		walls = g.T("walls.portal")
+	}
+	ui.DrawText(screen, "center", g.T("welcome.walls", walls), FontS, 2.2)
+	ui.DrawText(screen, "center", texts.Title, FontL, 4)
+	for i, line := range texts.Tagline {
+		ui.DrawText(screen, "center", line, FontL, 6+1.5*float32(i))
+	}
 
 	// Draw the welcome animation
 	ui.DrawWelcomeAnimation(screen, g, ui.Theme)
 
+	// Stack the pickers above the shape
+	pickerY := float32(9)
+	if len(g.Languages) > 1 {
+		ui.DrawText(screen, "center", g.T("welcome.language", g.T("language.name")), FontS, pickerY)
+		pickerY -= 0.7
+	}
+	if len(g.Campaigns) > 1 {
+		ui.DrawText(screen, "center", g.T("welcome.campaign", g.Campaign.Name), FontS, pickerY)
+	}
+
+	if g.SavedGame != nil {
+		ui.DrawText(screen, "center", g.T("welcome.continue"), FontM, 17.3)
+	}
 	if g.BlinkText {
-		ui.DrawText(screen, "center", "Press P to play or Q to quit", FontM, 18.5)
+		ui.DrawText(screen, "center", g.T("welcome.prompt"), FontM, 18.5)
 	}
 }
 
@@ -102,12 +138,19 @@ func (ui *UI) DrawWelcomePage(screen *ebiten.Image, g *Game) {
 func (ui *UI) DrawPlayPage(screen *ebiten.Image, g *Game) {
 	ui.DrawBaseElements(screen, g.DebugMode)
 
-	scale, x, y := PlaceDataPoint(g.CurrentDataPoint)
-	g.UI.DrawImage(screen, g.CurrentDataPoint.GetImage(), scale, x, y)
+	ui.DrawWalls(screen, g.Walls)
+
+	// Draw every data point on the board, blinking the ones about to expire
+	for _, dp := range g.DataPoints {
+		if movesLeft := g.MovesLeft(dp); movesLeft < 0 || movesLeft > ExpiryWarningMoves || g.Tick/int64(BlinkFreq)%2 == 0 {
+			scale, x, y := g.Campaign.PlaceDataPoint(dp)
+			g.UI.DrawImage(screen, g.Campaign.DataPointImage(dp), scale, x, y)
+		}
+	}
 
 	// Draw the snake based on visibility state
 	if g.SnakeVisible {
-		snakeImage := g.Snake.GetImage() // Get the snake image
+		snakeImage := g.Campaign.SnakeImg // Get the snake image
 		for _, segment := range g.Snake.Body {
 			segmentX, segmentY := segment[0]*ScreenUnit, segment[1]*ScreenUnit
 			// Draw the snake segment image
@@ -115,10 +158,67 @@ func (ui *UI) DrawPlayPage(screen *ebiten.Image, g *Game) {
 		}
 	}
 
-	scoreDisplay := fmt.Sprintf("Score: %d", g.Score)
+	if g.Regulator != nil {
+		ui.DrawRegulator(screen, float64(g.Regulator.X*ScreenUnit), float64(g.Regulator.Y*ScreenUnit))
+	}
+
+	ui.drawEffects(screen, g)
+
+	scoreDisplay := g.T("hud.score", g.Score)
 	ui.DrawText(screen, "left", scoreDisplay, FontM, 17)
-	levelDisplay := fmt.Sprintf("Level: %s", g.Level)
+	levelDisplay := g.T("hud.level", g.LocalizedLevel(g.Level))
 	ui.DrawText(screen, "right", levelDisplay, FontM, 17)
+	ui.DrawText(screen, "left", g.T("hud.speed", g.Speed()), FontS, 17.8)
+	if g.testing {
+		ui.DrawText(screen, "center", g.T("editor.testing"), FontS, 18.5)
+	}
+}
+
+// Draw the icon, name and moves left of every effect in progress above the play area
+func (ui *UI) drawEffects(screen *ebiten.Image, g *Game) {
+	xUnits := float32(1)
+	for _, active := range g.ActiveEffects {
+		ui.DrawImage(screen, EffectIcon(active.Slug), 0.8, float64(xUnits*ScreenUnit), float64(ScreenUnit*0.1))
+		label := g.T("hud.effect", g.EffectName(active.Slug), g.EffectMovesLeft(active))
+		ui.DrawTextAt(screen, label, FontS, xUnits+1, 0.8)
+		xUnits += 1.5 + float32(typeset.TextWidth(FontS, label))/ScreenUnit
+	}
+}
+
+// Draw the walls of the level, inset so they read apart from the snake
+func (ui *UI) DrawWalls(screen *ebiten.Image, walls sim.Layout) {
+	for cell := range walls {
+		vector.DrawFilledRect(screen, cell[0]*ScreenUnit+2, cell[1]*ScreenUnit+2, ScreenUnit-4, ScreenUnit-4, ui.Theme.Grid, false)
+		vector.StrokeRect(screen, cell[0]*ScreenUnit+2, cell[1]*ScreenUnit+2, ScreenUnit-4, ScreenUnit-4, 2, ui.Theme.DrawElement, false)
+	}
+}
+
+// Draws the Editor Page, painting the walls of an arena on the grid
+func (ui *UI) DrawEditorPage(screen *ebiten.Image, g *Game) {
+	ui.DrawBaseElements(screen, g.DebugMode)
+	ui.DrawGrid(screen)
+	ui.DrawWalls(screen, g.EditorArena.Walls)
+
+	// Draw the snake at its spawn, with an arrow ahead of its head
+	spawn := g.EditorArena.Spawn
+	for _, segment := range sim.NewSnake(spawn).Body {
+		ui.DrawImage(screen, g.Campaign.SnakeImg, 1.0, float64(segment[0]*ScreenUnit), float64(segment[1]*ScreenUnit))
+	}
+	moveX, moveY := spawn.Dir.Vector()
+	ui.DrawTextAt(screen, []string{"^", "v", "<", ">"}[spawn.Dir], FontM, spawn.X+float32(moveX)+0.3, spawn.Y+float32(moveY)+0.9)
+
+	// Frame the cell under the cursor
+	if cell, inside := cursorCell(); inside {
+		vector.StrokeRect(screen, cell[0]*ScreenUnit, cell[1]*ScreenUnit, ScreenUnit, ScreenUnit, 2, ui.Theme.DrawElement, false)
+	}
+
+	ui.DrawText(screen, "center", g.T("editor.title"), FontS, 0.8)
+	ui.DrawText(screen, "left", g.T("editor.walls", len(g.EditorArena.Walls)), FontM, 17)
+	if g.EditorStatus != "" {
+		ui.DrawText(screen, "right", g.EditorStatus, FontS, 17)
+	}
+	ui.DrawText(screen, "center", g.T("editor.paint"), FontS, 17.9)
+	ui.DrawText(screen, "center", g.T("editor.prompt"), FontS, 18.6)
 }
 
 // Draws the Special Page
@@ -126,30 +226,30 @@ func (ui *UI) DrawSpecialPage(screen *ebiten.Image, g *Game) {
 	ui.DrawBaseElements(screen, g.DebugMode)
 
 	name := g.CurrentSpecialDataPoint.Name
-	image := g.CurrentSpecialDataPoint.Image
-	textStr := g.CurrentSpecialDataPoint.Text
-	maxLineWidth := int(ScreenWidth) - 11*int(ScreenUnit)
+	image := g.Campaign.SpecialDataPointImage(g.CurrentSpecialDataPoint)
+	textStr := g.CurrentSpecialDataPoint.LocalizedText(g.Language)
 
-	ui.DrawText(screen, "center", "Congrats! You've just acquired:", FontL, 3.5)
+	ui.DrawText(screen, "center", g.T("special.title"), FontL, 3.5)
 	ui.DrawText(screen, "center", name, FontL, 5)
 
 	scale, x, y := ui.PlaceImage(image, 6, 3, "center")
 	ui.DrawImage(screen, image, scale, x, y)
-	ui.DrawMultiLineText(screen, textStr, 7.5, 10.5, FontM, maxLineWidth, g.CurrentCharIndex)
+	ui.DrawText(screen, "center", g.CurrentSpecialDataPoint.LocalizedSummary(g.Language), FontS, 9.5)
+	ui.DrawMultiLineText(screen, textStr, 7.5, 10.5, FontM, typeset.SpecialTextWidth, g.CurrentCharIndex)
 
 	ui.DrawEvil(screen, float64(ScreenUnit)*2, float64(PlayAreaHeight)-float64(ScreenUnit)*5)
 	ui.DrawFire(screen, float64(PlayAreaHeight)-float64(ScreenUnit)*0.7)
 
-	scoreDisplay := fmt.Sprintf("Score: %d", g.Score)
+	scoreDisplay := g.T("hud.score", g.Score)
 	ui.DrawText(screen, "left", scoreDisplay, FontM, 17)
-	levelDisplay := fmt.Sprintf("Level: %s", g.Level)
+	levelDisplay := g.T("hud.level", g.LocalizedLevel(g.Level))
 	ui.DrawText(screen, "right", levelDisplay, FontM, 17)
 
-	totalLength := len(textStr)
+	totalLength := utf8.RuneCountInString(textStr)
 
 	if g.CurrentCharIndex >= totalLength {
 		if g.BlinkText {
-			ui.DrawText(screen, "center", "Press R to resume or Q to quit", FontM, 18.5)
+			ui.DrawText(screen, "center", g.T("special.prompt"), FontM, 18.5)
 		}
 	}
 }
@@ -158,42 +258,302 @@ func (ui *UI) DrawSpecialPage(screen *ebiten.Image, g *Game) {
 func (ui *UI) DrawGameOverPage(screen *ebiten.Image, g *Game) {
 	ui.DrawBaseElements(screen, g.DebugMode)
 
-	scoreDisplay := fmt.Sprintf("Score: %d", g.Score)
-	levelDisplay := fmt.Sprintf("Level: %s", g.Level)
+	texts := g.Campaign.CopyFor(g.Language)
+	scoreDisplay := g.T("hud.score", g.Score)
+	levelDisplay := g.T("hud.level", g.LocalizedLevel(g.Level))
+	seedDisplay := g.T("gameover.seed", g.Seed)
+	if g.LeaderboardStatus != "" {
+		seedDisplay += "  |  " + g.LeaderboardStatus
+	}
 
-	ui.DrawText(screen, "center", "GAME OVER", FontXL, 4)
+	ui.DrawText(screen, "center", g.T("gameover.title"), FontXL, 4)
 	ui.DrawText(screen, "center", scoreDisplay, FontM, 6)
 	ui.DrawText(screen, "center", levelDisplay, FontM, 7)
-	ui.DrawText(screen, "center", "Oops! You've been out-monopolized.", FontM, 10)
-	ui.DrawText(screen, "center", "But don't worry, your data", FontM, 11)
-	ui.DrawText(screen, "center", "will live on forever with us.", FontM, 12)
+	ui.DrawText(screen, "center", BreakdownDisplay(g.Messages, g.Breakdown), FontS, 8)
+	ui.DrawText(screen, "center", seedDisplay, FontS, 9)
+	for i, line := range texts.GameOver {
+		ui.DrawText(screen, "center", line, FontM, 10+float32(i))
+	}
+	if g.NewHighScoreRank >= 0 {
+		ui.DrawText(screen, "center", g.T("gameover.highscore", g.NewHighScoreRank+1), FontM, 13.3)
+	}
 
 	ui.DrawFire(screen, float64(PlayAreaHeight)-float64(ScreenUnit)*0.7)
 
 	if g.BlinkText {
-		ui.DrawText(screen, "center", "Press P to play or Q to quit", FontM, 18.5)
+		ui.DrawText(screen, "center", g.T("gameover.prompt"), FontM, 18.5)
 	}
 }
 
-// Draws the Goal Page
+// Draws the Goal Page, with the timeline of the acquisitions of the run
 func (ui *UI) DrawGoalPage(screen *ebiten.Image, g *Game) {
 	ui.DrawBaseElements(screen, g.DebugMode)
 
-	// scoreDisplay := fmt.Sprintf("Score: %d", score)
-	// levelDisplay := fmt.Sprintf("Level: XXX")
+	texts := g.Campaign.CopyFor(g.Language)
+	timeline := g.Timeline()
+
+	ui.DrawText(screen, "center", g.T("goal.title"), FontXL, 2.7)
+	ui.DrawText(screen, "center", texts.Motto, FontM, 3.9)
+	ui.drawTimelineSummary(screen, g, len(timeline), 5)
+
+	// Draw the acquisitions revealed so far, in the scrolled window
+	last := g.TimelineScroll + TimelineRows
+	if revealed := g.TimelineRevealed(); last > revealed {
+		last = revealed
+	}
+	for i := g.TimelineScroll; i < last; i++ {
+		ui.drawTimelineEntry(screen, g, timeline[i], TimelineY+float32(i-g.TimelineScroll))
+	}
+	if len(timeline) > TimelineRows {
+		ui.DrawText(screen, "right", g.T("timeline.scroll", g.TimelineScroll+1, g.TimelineScroll+TimelineRows, len(timeline)), FontS, TimelineY+float32(TimelineRows))
+	}
 
-	ui.DrawText(screen, "center", "CONGRATULATIONS !", FontXL, 4)
-	ui.DrawText(screen, "center", "Master of the Digital Panopticon !", FontL, 7)
-	ui.DrawText(screen, "center", "In the world of Surveillance Capitalism,", FontL, 8.5)
-	ui.DrawText(screen, "center", "you stand unrivaled !", FontL, 10)
-	ui.DrawText(screen, "center", "A true data supremacist !!!", FontL, 13)
 	ui.DrawFire(screen, float64(PlayAreaHeight)-float64(ScreenUnit)*0.7)
+	if g.ExportStatus != "" {
+		ui.DrawText(screen, "center", g.ExportStatus, FontS, 16.9)
+	}
+	if g.LeaderboardStatus != "" {
+		ui.DrawText(screen, "center", g.LeaderboardStatus, FontS, 17.6)
+	}
+
+	if g.BlinkText {
+		ui.DrawText(screen, "center", g.T("goal.prompt"), FontM, 18.5)
+	}
+}
+
+// Draw the whole timeline of the run on a new image, as exported to share the empire
+func (ui *UI) DrawTimelineImage(g *Game) *ebiten.Image {
+	texts := g.Campaign.CopyFor(g.Language)
+	timeline := g.Timeline()
+
+	// Stack the copy, the summary, every acquisition and the footer
+	summaryY := 4 + float32(len(texts.Goal))
+	entriesY := summaryY + TimelineY - 5
+	footerY := entriesY + float32(len(timeline)) + 0.5
+	img := ebiten.NewImage(int(ScreenWidth), int(ScreenUnit*(footerY+1)))
+	img.Fill(ui.Theme.Background)
+	vector.StrokeRect(img, ScreenUnit/2, ScreenUnit/2, ScreenWidth-ScreenUnit, float32(img.Bounds().Dy())-ScreenUnit, 2, ui.Theme.DrawElement, false)
+
+	ui.DrawText(img, "center", g.T("goal.title"), FontXL, 2.7)
+	for i, line := range texts.Goal {
+		ui.DrawText(img, "center", line, FontM, 3.9+float32(i))
+	}
+	ui.drawTimelineSummary(img, g, len(timeline), summaryY)
+	for i, entry := range timeline {
+		ui.drawTimelineEntry(img, g, entry, entriesY+float32(i))
+	}
+	ui.DrawText(img, "center", g.T("timeline.footer", texts.Motto, g.Campaign.Name, g.Seed), FontS, footerY)
+	return img
+}
+
+// Draw the score, the play time, the breakdown and the level path of the run from yUnits down
+func (ui *UI) drawTimelineSummary(screen *ebiten.Image, g *Game, acquisitions int, yUnits float32) {
+	var levels []string
+	for _, level := range g.LevelPath() {
+		levels = append(levels, g.LocalizedLevel(level))
+	}
+
+	ui.DrawText(screen, "center", g.T("timeline.summary", g.Score, FormatPlayTime(PlayTicks(g.PlayTime)), acquisitions), FontM, yUnits)
+	ui.DrawText(screen, "center", BreakdownDisplay(g.Messages, g.Breakdown), FontS, yUnits+0.8)
+	for i, line := range JoinLines(levels, " > ", FontS, typeset.FactLineWidth) {
+		ui.DrawText(screen, "center", line, FontS, yUnits+1.5+0.6*float32(i))
+	}
+}
+
+// Join items with sep into lines no wider than maxLineWidth, breaking only between items
+func JoinLines(items []string, sep string, fontFace font.Face, maxLineWidth int) []string {
+	var lines []string
+	for _, item := range items {
+		if len(lines) > 0 && typeset.TextWidth(fontFace, lines[len(lines)-1]+sep+item) <= maxLineWidth {
+			lines[len(lines)-1] += sep + item
+		} else {
+			lines = append(lines, item)
+		}
+	}
+	return lines
+}
+
+// Draw an acquisition of the timeline: icon, year, name and the simulation tick and play time it was captured at
+func (ui *UI) drawTimelineEntry(screen *ebiten.Image, g *Game, entry sim.TimelineEntry, yUnits float32) {
+	ui.DrawImage(screen, g.Campaign.SpecialDataPointImage(entry.SpecialDataPoint), 0.8, float64(ScreenUnit), float64(ScreenUnit*(yUnits-0.85)))
+	ui.DrawTextAt(screen, fmt.Sprint(entry.Year), FontM, 2.2, yUnits)
+	ui.DrawTextAt(screen, entry.Name, FontM, 4.4, yUnits)
+	ui.DrawText(screen, "right", g.T("timeline.tick", entry.Step, FormatPlayTime(PlayTicks(entry.Time))), FontS, yUnits)
+}
+
+// Draws the Initials Page, shown when a run enters the high score table or goes to the leaderboard
+func (ui *UI) DrawInitialsPage(screen *ebiten.Image, g *Game) {
+	ui.DrawBaseElements(screen, g.DebugMode)
+
+	title := g.T("initials.submit")
+	if g.persist && g.HighScores.Qualifies(g.Score) {
+		title = g.T("initials.highscore")
+	}
+	scoreDisplay := g.T("hud.score", g.Score)
+	initialsDisplay := g.Initials + strings.Repeat("_", MaxInitials-len(g.Initials))
+
+	ui.DrawText(screen, "center", title, FontXL, 4)
+	ui.DrawText(screen, "center", scoreDisplay, FontM, 6)
+	ui.DrawText(screen, "center", g.T("initials.prompt"), FontM, 8)
+	ui.DrawText(screen, "center", strings.Join(strings.Split(initialsDisplay, ""), " "), FontXXL, 11)
+
+	if g.BlinkText {
+		ui.DrawText(screen, "center", g.T("initials.confirm"), FontM, 18.5)
+	}
+}
+
+// Draws the High Scores Page
+func (ui *UI) DrawHighScoresPage(screen *ebiten.Image, g *Game) {
+	ui.DrawBaseElements(screen, g.DebugMode)
+
+	ui.DrawText(screen, "center", g.T("highscores.title"), FontXL, 3)
+
+	if len(g.HighScores) == 0 {
+		ui.DrawText(screen, "center", g.T("highscores.empty"), FontM, 8)
+	}
+	for i, entry := range g.HighScores {
+		row := g.T("highscores.row", i+1, entry.Initials, entry.Score, g.LocalizedLevel(entry.Level), entry.Acquisitions, entry.Date.Format("2006-01-02"))
+		ui.DrawText(screen, "center", row, FontS, 5+float32(i))
+	}
+
+	if g.BlinkText {
+		ui.DrawText(screen, "center", g.T("highscores.prompt"), FontM, 18.5)
+	}
+}
+
+// Draws the Codex Page, listing the special data points of the campaign unlocked across all runs
+func (ui *UI) DrawCodexPage(screen *ebiten.Image, g *Game) {
+	ui.DrawBaseElements(screen, g.DebugMode)
+
+	ui.DrawText(screen, "center", g.T("codex.title"), FontXL, 3)
+
+	entries := g.Campaign.SpecialDataPoints
+	campaignID := g.Campaign.ID()
+
+	// Draw a strip of icons around the selected entry, locked ones as silhouettes
+	first := g.CodexIndex - CodexStripSize/2
+	if first > len(entries)-CodexStripSize {
+		first = len(entries) - CodexStripSize
+	}
+	if first < 0 {
+		first = 0
+	}
+	last := first + CodexStripSize
+	if last > len(entries) {
+		last = len(entries)
+	}
+	stripX := (ScreenWidth - float32(last-first)*ScreenUnit) / 2
+	stripY := ScreenUnit * 4.2
+	for i := first; i < last; i++ {
+		x := stripX + float32(i-first)*ScreenUnit
+		ui.drawCodexIcon(screen, g.Campaign.SpecialDataPointImage(entries[i]), g.Codex.Unlocked(campaignID, entries[i].Slug), 1, float64(x)+1, float64(stripY)+1)
+		if i == g.CodexIndex {
+			vector.StrokeRect(screen, x, stripY, ScreenUnit, ScreenUnit, 2, ui.Theme.DrawElement, false)
+		}
+	}
+
+	// Draw the selected entry
+	if len(entries) > 0 {
+		entry := entries[g.CodexIndex]
+		unlocked := g.Codex.Unlocked(campaignID, entry.Slug)
+		ui.drawCodexIcon(screen, g.Campaign.SpecialDataPointImage(entry), unlocked, 3, float64(ScreenUnit)*2, float64(ScreenUnit)*9.6)
+		if unlocked {
+			textStr := entry.LocalizedText(g.Language)
+			ui.DrawText(screen, "center", entry.Name, FontL, 7.3)
+			ui.DrawText(screen, "center", g.T("codex.entry", entry.Year, g.LocalizedLevel(entry.Level)), FontM, 8.4)
+			ui.DrawText(screen, "center", entry.LocalizedSummary(g.Language), FontS, 9.2)
+			ui.DrawMultiLineText(screen, textStr, 7.5, 10.4, FontM, typeset.SpecialTextWidth, utf8.RuneCountInString(textStr))
+
+			// Draw the facts under the icon and the sources at the bottom
+			ui.DrawText(screen, "left", entry.LocalizedCategory(g.Language), FontS, 13.6)
+			ui.DrawText(screen, "left", entry.Price, FontS, 14.3)
+			if len(entry.Sources) > 0 {
+				ui.DrawText(screen, "center", g.T("codex.sources", strings.Join(entry.Sources, "; ")), FontS, 17)
+			}
+		} else {
+			ui.DrawText(screen, "center", "???", FontL, 7.3)
+			ui.DrawText(screen, "center", g.T("codex.locked"), FontM, 8.4)
+		}
+	}
+
+	ui.DrawText(screen, "center", g.T("codex.progress", g.Codex.Count(campaignID, entries), len(entries), g.Campaign.Name), FontS, 6)
 
 	if g.BlinkText {
-		ui.DrawText(screen, "center", "Press P to replay or Q to quit", FontM, 18.5)
+		ui.DrawText(screen, "center", g.T("codex.prompt"), FontM, 18.5)
 	}
 }
 
+// Draws the Quiz Page, asking about the acquisitions of the run
+func (ui *UI) DrawQuizPage(screen *ebiten.Image, g *Game) {
+	ui.DrawBaseElements(screen, g.DebugMode)
+
+	quiz := g.Quiz
+	question := quiz.Question()
+	title := g.T("quiz.level")
+	if quiz.Outcome != PlayState {
+		title = g.T("quiz.final")
+	}
+
+	ui.DrawText(screen, "center", title, FontXL, 3)
+	ui.DrawText(screen, "center", g.T("quiz.progress", quiz.Current+1, len(quiz.Questions), sim.QuizPoints), FontS, 4.5)
+	questionStr := g.QuestionDisplay(question)
+	ui.DrawMultiLineText(screen, questionStr, 1, 6.5, FontM, typeset.FactLineWidth, utf8.RuneCountInString(questionStr))
+
+	for i, choice := range question.Choices {
+		choiceStr := ChoiceDisplay(i, choice)
+		// Mark the right choice, and the wrong one picked, once answered
+		if quiz.Answered && i == question.Answer {
+			choiceStr += "  <"
+		} else if quiz.Answered && i == quiz.Choice {
+			choiceStr += "  x"
+		}
+		ui.DrawText(screen, "left", choiceStr, FontM, 10+1.2*float32(i))
+	}
+
+	if quiz.Answered {
+		feedback := g.T("quiz.wrong", question.Choices[question.Answer])
+		if quiz.Choice == question.Answer {
+			feedback = g.T("quiz.right", sim.QuizPoints)
+		}
+		ui.DrawText(screen, "center", feedback, FontM, 15.5)
+	}
+
+	ui.DrawText(screen, "left", g.T("hud.score", g.Score), FontM, 17)
+	ui.DrawText(screen, "right", g.T("quiz.session", CorrectAnswers(g.QuizResults), len(g.QuizResults)), FontM, 17)
+
+	if g.BlinkText {
+		prompt := g.T("quiz.prompt")
+		if quiz.Answered {
+			prompt = g.T("quiz.next")
+		}
+		ui.DrawText(screen, "center", prompt, FontM, 18.5)
+	}
+}
+
+// Draw a codex icon, as a silhouette in the grid color if it is still locked
+func (ui *UI) drawCodexIcon(screen *ebiten.Image, img *ebiten.Image, unlocked bool, scale, x, y float64) {
+	if unlocked {
+		ui.DrawImage(screen, img, scale, x, y)
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x, y)
+	screen.DrawImage(ApplyMonochromeFilter(img, ui.Theme.Grid), op)
+}
+
+// Describe how the score was earned
+func BreakdownDisplay(messages i18n.Catalog, breakdown sim.ScoreBreakdown) string {
+	display := messages.T("breakdown", breakdown.Base, breakdown.Streak, breakdown.SpeedBonus, breakdown.BestStreak)
+	if breakdown.Quiz > 0 {
+		display = messages.T("breakdown.quiz", display, breakdown.Quiz)
+	}
+	if breakdown.Fines > 0 {
+		display = messages.T("breakdown.fines", display, breakdown.Fines)
+	}
+	return display
+}
+
 // Draws text aligned to the specified side (left or right)
 func (ui *UI) DrawText(screen *ebiten.Image, alignment string, textStr string, fontFace font.Face, yUnits float32) {
 	// Calculate the text width
@@ -217,52 +577,27 @@ func (ui *UI) DrawText(screen *ebiten.Image, alignment string, textStr string, f
 	text.Draw(screen, textStr, fontFace, int(x), y, ui.Theme.DrawElement)
 }
 
-func (ui *UI) DrawMultiLineText(screen *ebiten.Image, textStr string, xUnits, yUnits float32, fontFace font.Face, maxLineWidth int, currentCharIndex int) {
-	// Add double quotes at the beginning and end of the textStr
-	textStr = "\"" + textStr + "\""
+// Draws text starting at xUnits
+func (ui *UI) DrawTextAt(screen *ebiten.Image, textStr string, fontFace font.Face, xUnits, yUnits float32) {
+	y := int(ScreenUnit*yUnits - ScreenUnit*0.1)
+	text.Draw(screen, textStr, fontFace, int(ScreenUnit*xUnits), y, ui.Theme.DrawElement)
+}
 
-	// Split the text into words
-	words := strings.Fields(textStr)
-	var lines []string
-	var currentLine string
+func (ui *UI) DrawMultiLineText(screen *ebiten.Image, textStr string, xUnits, yUnits float32, fontFace font.Face, maxLineWidth int, currentCharIndex int) {
+	lines := typeset.WrapText(textStr, fontFace, maxLineWidth)
 	x := int(ScreenUnit * xUnits)
 	y := int(ScreenUnit*yUnits - ScreenUnit*0.1)
 
-	for _, word := range words {
-		// Check line width with the new word added
-		testLine := currentLine
-		if currentLine != "" {
-			testLine += " " // Add a space before the word if it's not the first word in the line
-		}
-		testLine += word
-
-		bounds, _ := font.BoundString(fontFace, testLine)
-		lineWidth := (bounds.Max.X - bounds.Min.X).Ceil()
-
-		if lineWidth <= maxLineWidth {
-			// If it fits, add the word to the current line
-			currentLine = testLine
-		} else {
-			// If it doesn't fit, start a new line
-			if currentLine != "" {
-				lines = append(lines, currentLine)
-			}
-			currentLine = word
-		}
-	}
-
-	// Add the last line
-	lines = append(lines, currentLine)
-
-	// Draw each line, up to currentCharIndex
+	// Draw each line, up to currentCharIndex, counting characters rather than bytes
 	charsDrawn := 0
 	for i, line := range lines {
-		if charsDrawn+len(line) > currentCharIndex {
-			line = line[:currentCharIndex-charsDrawn]
+		chars := []rune(line)
+		if charsDrawn+len(chars) > currentCharIndex {
+			chars = chars[:currentCharIndex-charsDrawn]
 		}
 		lineSpacing := i * int(ScreenUnit)
-		text.Draw(screen, line, fontFace, x, y+lineSpacing, ui.Theme.DrawElement)
-		charsDrawn += len(line)
+		text.Draw(screen, string(chars), fontFace, x, y+lineSpacing, ui.Theme.DrawElement)
+		charsDrawn += len(chars)
 		if charsDrawn >= currentCharIndex {
 			break
 		}
@@ -298,11 +633,6 @@ func (ui *UI) DrawImage(screen *ebiten.Image, img *ebiten.Image, scale, x, y flo
 	screen.DrawImage(filteredImage, op)
 }
 
-// SetScore sets the current score to be displayed in the UI
-func (ui *UI) SetScore(score int8) {
-	ui.score = score
-}
-
 // SetGameOver sets the game over state and triggers a game over message to be displayed
 func (ui *UI) SetGameOver() {
 	ui.gameOver = true
@@ -381,6 +711,11 @@ func (ui *UI) DrawEvil(screen *ebiten.Image, x, y float64) {
 	ui.DrawChar(screen, EvilShape, x, y, 8)
 }
 
+// Draw the regulator in the grid cell at x, y
+func (ui *UI) DrawRegulator(screen *ebiten.Image, x, y float64) {
+	ui.DrawChar(screen, RegulatorShape, x, y, math.Round(ShapePixelSize))
+}
+
 func (ui *UI) DrawGEvil(screen *ebiten.Image, x, y float64) {
 	ui.DrawChar(screen, GEvilShape, x, y, 8)
 }
@@ -391,15 +726,15 @@ func (ui *UI) DrawAsciiArt(screen *ebiten.Image, asciiArt []string, x, y int) {
 	}
 }
 
-// DrawWelcomeAnimation draws the GShape and SixShape alternately
+// DrawWelcomeAnimation draws the campaign shape and SixShape alternately
 func (ui *UI) DrawWelcomeAnimation(screen *ebiten.Image, g *Game, initialUserTheme ColorTheme) {
 
 	// Calculate the center of the shape
-	shapeWidth := float64(len(GShape)) * ShapePixelSize
+	shapeWidth := float64(len(SixShape[0])) * ShapePixelSize
 	centerX := float64(ScreenWidth)/2 - float64(shapeWidth)/2
 
-	if !g.IsGShape {
-		ui.Theme = ApocalypseTheme
+	if !g.IsCampaignShape {
+		ui.Theme = g.Campaign.ApocalypseTheme
 		// Draw the shape
 		ui.DrawChar(screen, SixShape, centerX, float64(PlayAreaHeight)*0.65, 8)
 		ui.DrawChar(screen, SixShape, centerX-shapeWidth-ShapePixelSize, float64(PlayAreaHeight)*0.65, 8)
@@ -407,8 +742,9 @@ func (ui *UI) DrawWelcomeAnimation(screen *ebiten.Image, g *Game, initialUserThe
 		ui.DrawFire(screen, float64(PlayAreaHeight)-float64(ScreenUnit)*0.7)
 
 	} else {
-		ui.Theme = DayTheme
+		ui.Theme = g.Campaign.DayTheme
 		ui.DrawCity(screen, float64(PlayAreaHeight)-float64(ScreenUnit)*1.6)
-		ui.DrawChar(screen, GShape, centerX, float64(PlayAreaHeight)*0.65, 8)
+		campaignShapeWidth := float64(len(g.Campaign.Shape[0])) * ShapePixelSize
+		ui.DrawChar(screen, g.Campaign.Shape, float64(ScreenWidth)/2-campaignShapeWidth/2, float64(PlayAreaHeight)*0.65, 8)
 	}
 }
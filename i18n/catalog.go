@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Language every other one falls back to for missing messages
+const DefaultLanguage = "en"
+
+// Define a message catalog, format strings keyed by message ID
+type Catalog map[string]string
+
+// Load the catalog of lang from the <lang>.json file of fsys, over the DefaultLanguage one
+func Load(fsys fs.FS, lang string) (Catalog, error) {
+	catalog, err := readCatalog(fsys, DefaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+	if lang == DefaultLanguage {
+		return catalog, nil
+	}
+
+	translations, err := readCatalog(fsys, lang)
+	if err != nil {
+		return nil, err
+	}
+	for key, message := range translations {
+		catalog[key] = message
+	}
+	return catalog, nil
+}
+
+func readCatalog(fsys fs.FS, lang string) (Catalog, error) {
+	fileData, err := fs.ReadFile(fsys, lang+".json")
+	if err != nil {
+		return nil, err
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(fileData, &catalog); err != nil {
+		return nil, fmt.Errorf("%s.json: %w", lang, err)
+	}
+	return catalog, nil
+}
+
+// List the languages of the catalogs in fsys, in name order
+func Languages(fsys fs.FS) ([]string, error) {
+	matches, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]string, len(matches))
+	for i, match := range matches {
+		languages[i] = strings.TrimSuffix(path.Base(match), ".json")
+	}
+	sort.Strings(languages)
+	return languages, nil
+}
+
+// Return the message of key formatted with args, or key itself if it is missing
+func (c Catalog) T(key string, args ...any) string {
+	message, found := c[key]
+	if !found {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,98 @@
+package i18n_test
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/szkjn/snakeopoly-go/i18n"
+)
+
+var locales = fstest.MapFS{
+	"en.json": {Data: []byte(`{"greeting": "Hello %s", "farewell": "Bye"}`)},
+	"fr.json": {Data: []byte(`{"greeting": "Bonjour %s"}`)},
+}
+
+func TestLoadFallsBackToEnglish(t *testing.T) {
+	catalog, err := i18n.Load(locales, "fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := catalog.T("greeting", "Ada"); got != "Bonjour Ada" {
+		t.Errorf("greeting = %q, want the French one", got)
+	}
+	if got := catalog.T("farewell"); got != "Bye" {
+		t.Errorf("farewell = %q, want the English one", got)
+	}
+}
+
+func TestLoadRejectsUnknownLanguages(t *testing.T) {
+	if _, err := i18n.Load(locales, "de"); err == nil {
+		t.Error("loaded a language without a catalog")
+	}
+}
+
+func TestMissingMessagesShowTheirKey(t *testing.T) {
+	catalog, err := i18n.Load(locales, i18n.DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := catalog.T("missing", 1); got != "missing" {
+		t.Errorf("missing message = %q, want its key", got)
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	languages, err := i18n.Languages(locales)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(languages) != 2 || languages[0] != "en" || languages[1] != "fr" {
+		t.Errorf("languages = %v, want [en fr]", languages)
+	}
+}
+
+// Every embedded translation only holds messages of the English catalog, with the same arguments
+func TestEmbeddedCatalogsTranslateEnglish(t *testing.T) {
+	fsys := os.DirFS("../assets/locales")
+	english, err := i18n.Load(fsys, i18n.DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	languages, err := i18n.Languages(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lang := range languages {
+		catalog, err := i18n.Load(fsys, lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(catalog) != len(english) {
+			t.Errorf("%s: %d messages, English has %d", lang, len(catalog), len(english))
+		}
+		for key, message := range catalog {
+			if verbs(message) != verbs(english[key]) {
+				t.Errorf("%s: %q is %q, which formats other arguments than %q", lang, key, message, english[key])
+			}
+		}
+	}
+}
+
+// Return the formatting verbs of message, in order
+func verbs(message string) string {
+	var verbs []byte
+	for i := 0; i < len(message)-1; i++ {
+		if message[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(message) && (message[i] == '.' || message[i] >= '0' && message[i] <= '9') {
+			i++
+		}
+		if i < len(message) {
+			verbs = append(verbs, message[i])
+		}
+	}
+	return string(verbs)
+}
@@ -0,0 +1,70 @@
+package leaderboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Give up on the server after this long, so an offline leaderboard never stalls the game
+const ClientTimeout = 5 * time.Second
+
+// Define a client of a leaderboard server
+type Client struct {
+	URL  string // Base URL of the server
+	HTTP *http.Client
+}
+
+// Initialize and return a new client of the server at url
+func NewClient(url string) *Client {
+	return &Client{
+		URL:  strings.TrimSuffix(url, "/"),
+		HTTP: &http.Client{Timeout: ClientTimeout},
+	}
+}
+
+// Submit a run, returning its ranks once the server has verified it
+func (c *Client) Submit(submission Submission) (SubmitResult, error) {
+	var result SubmitResult
+	body, err := json.Marshal(submission)
+	if err != nil {
+		return result, err
+	}
+
+	resp, err := c.HTTP.Post(c.URL+"/scores", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	err = decodeResponse(resp, http.StatusCreated, &result)
+	return result, err
+}
+
+// List the n best entries of the server
+func (c *Client) Top(n int) ([]Entry, error) {
+	resp, err := c.HTTP.Get(fmt.Sprintf("%s/scores?limit=%d", c.URL, n))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var entries []Entry
+	err = decodeResponse(resp, http.StatusOK, &entries)
+	return entries, err
+}
+
+// Decode the response into v, turning unexpected statuses into errors
+func decodeResponse(resp *http.Response, status int, v any) error {
+	if resp.StatusCode != status {
+		var errResp errorResponse
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error != "" {
+			return fmt.Errorf("leaderboard: %s", errResp.Error)
+		}
+		return fmt.Errorf("leaderboard: unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
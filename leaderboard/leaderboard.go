@@ -0,0 +1,59 @@
+package leaderboard
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Define a run submitted to the leaderboard, with the log proving its score
+type Submission struct {
+	Initials     string
+	Score        int64
+	Level        string
+	Acquisitions int
+	Replay       sim.RunLog
+}
+
+// Define an entry of the leaderboard
+type Entry struct {
+	sim.HighScore
+	Difficulty string
+	Borderless bool   `json:",omitempty"`
+	Quiz       bool   `json:",omitempty"`
+	Run        string `json:",omitempty"` // RunID of the log proving the score, telling resubmissions apart
+}
+
+// Define the board of a seed, runs competing on it only against runs of the same campaign, difficulty and modes
+type SeedBoard struct {
+	Seed       int64
+	Campaign   string
+	Difficulty string
+	Borderless bool
+	Quiz       bool
+}
+
+// Return the board of the seed the entry competes on
+func (e Entry) SeedBoard() SeedBoard {
+	return SeedBoard{Seed: e.Seed, Campaign: e.Campaign, Difficulty: e.Difficulty, Borderless: e.Borderless, Quiz: e.Quiz}
+}
+
+// Define the answer to an accepted submission
+type SubmitResult struct {
+	Rank     int // Rank on the overall board, 0 for the best
+	SeedRank int // Rank on the board of the seed, campaign, difficulty and modes
+}
+
+// Define the body of an error response
+type errorResponse struct {
+	Error string
+}
+
+// Return a hash of the seed, campaign, difficulty, modes and inputs of a run, the same for every submission of the run
+func RunID(log sim.RunLog) string {
+	logData, _ := json.Marshal(log)
+	sum := sha256.Sum256(logData)
+	return hex.EncodeToString(sum[:])
+}
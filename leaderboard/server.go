@@ -0,0 +1,245 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/szkjn/snakeopoly-go/content"
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+const (
+	DefaultLimit   = sim.MaxHighScores // Entries listed when no limit is given
+	MaxLimit       = 100               // Most entries listed at once
+	MaxInitials    = 3
+	MaxSteps       = 1 << 20 // Longest run re-simulated
+	MaxVerifying   = 4       // Submissions re-simulated at once, the others being turned away until one is done
+	maxRequestSize = 1 << 20
+)
+
+// Define the leaderboard HTTP API, re-simulating every submission before storing it
+//
+//	POST /scores                 submit a Submission, answered with a SubmitResult
+//	GET  /scores?limit=N         list the N best entries
+//	GET  /seeds/{seed}/scores    list the best entries played with seed, in the campaign, difficulty and modes of the query
+type Server struct {
+	Store     *FileStore
+	Campaigns map[string]*content.Campaign // Campaigns runs are re-simulated with, by ID
+	Now       func() time.Time
+	verifying chan struct{} // Holds a value per submission being re-simulated
+}
+
+// Initialize and return a new server storing accepted runs of campaigns in store
+func NewServer(store *FileStore, campaigns []*content.Campaign) *Server {
+	s := &Server{
+		Store:     store,
+		Campaigns: map[string]*content.Campaign{},
+		Now:       time.Now,
+		verifying: make(chan struct{}, MaxVerifying),
+	}
+	for _, campaign := range campaigns {
+		s.Campaigns[campaign.ID()] = campaign
+	}
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/scores" {
+		if r.Method == http.MethodPost {
+			s.handleSubmit(w, r)
+		} else if r.Method == http.MethodGet {
+			s.handleList(w, r)
+		} else {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	// Handle /seeds/{seed}/scores
+	if seedPath, found := strings.CutPrefix(r.URL.Path, "/seeds/"); found {
+		if seedText, found := strings.CutSuffix(seedPath, "/scores"); found {
+			seed, err := strconv.ParseInt(seedText, 10, 64)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid seed")
+			} else if r.Method != http.MethodGet {
+				writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			} else {
+				s.handleSeedList(w, r, seed)
+			}
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "not found")
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var submission Submission
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&submission); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid submission: "+err.Error())
+		return
+	}
+
+	// Turn resubmissions away before re-simulating them, and bound the re-simulations running at once
+	run := RunID(submission.Replay)
+	if s.Store.HasRun(run) {
+		writeError(w, http.StatusConflict, ErrDuplicateRun.Error())
+		return
+	}
+	select {
+	case s.verifying <- struct{}{}:
+	default:
+		writeError(w, http.StatusServiceUnavailable, "too many submissions being verified, try again later")
+		return
+	}
+	err := s.Verify(submission)
+	<-s.verifying
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	entry := Entry{
+		HighScore: sim.HighScore{
+			Initials:     submission.Initials,
+			Campaign:     submission.Replay.Campaign,
+			Score:        submission.Score,
+			Level:        submission.Level,
+			Acquisitions: submission.Acquisitions,
+			Seed:         submission.Replay.Seed,
+			Date:         s.Now(),
+		},
+		Difficulty: submission.Replay.Difficulty.String(),
+		Borderless: submission.Replay.Borderless,
+		Quiz:       submission.Replay.Quiz,
+		Run:        run,
+	}
+	result, err := s.Store.Add(entry)
+	if errors.Is(err, ErrDuplicateRun) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to store the score")
+		return
+	}
+	writeJSON(w, http.StatusCreated, result)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, s.Store.Top(limit))
+}
+
+// List the best entries of the seed, for the campaign, the difficulty (normal by default) and the modes of the query
+func (s *Server) handleSeedList(w http.ResponseWriter, r *http.Request, seed int64) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	board := SeedBoard{Seed: seed, Campaign: query.Get("campaign"), Difficulty: sim.Normal.String()}
+	if _, found := s.Campaigns[board.Campaign]; !found {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown campaign %q", board.Campaign))
+		return
+	}
+	if difficultyText := query.Get("difficulty"); difficultyText != "" {
+		difficulty, err := sim.ParseDifficulty(difficultyText)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		board.Difficulty = difficulty.String()
+	}
+	for _, mode := range []struct {
+		name  string
+		value *bool
+	}{{"borderless", &board.Borderless}, {"quiz", &board.Quiz}} {
+		if modeText := query.Get(mode.name); modeText != "" {
+			if *mode.value, err = strconv.ParseBool(modeText); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid "+mode.name)
+				return
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, s.Store.TopForSeed(board, limit))
+}
+
+// Read the limit of the query, capped to MaxLimit
+func parseLimit(r *http.Request) (int, error) {
+	limit := DefaultLimit
+	if limitText := r.URL.Query().Get("limit"); limitText != "" {
+		var err error
+		limit, err = strconv.Atoi(limitText)
+		if err != nil || limit < 0 {
+			return 0, errors.New("invalid limit")
+		}
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return limit, nil
+}
+
+// Check a submission by re-simulating its run and comparing the outcome
+func (s *Server) Verify(submission Submission) error {
+	if len(submission.Initials) == 0 || len(submission.Initials) > MaxInitials {
+		return fmt.Errorf("initials must be 1 to %d characters", MaxInitials)
+	}
+	for _, char := range submission.Initials {
+		if !unicode.IsUpper(char) && !unicode.IsDigit(char) || char >= unicode.MaxASCII {
+			return errors.New("initials must be uppercase letters or digits")
+		}
+	}
+
+	if submission.Score <= 0 {
+		return errors.New("score must be positive")
+	}
+
+	replay := submission.Replay
+	if _, err := sim.ParseDifficulty(replay.Difficulty.String()); err != nil {
+		return err
+	}
+	if replay.Steps < 0 || replay.Steps > MaxSteps {
+		return errors.New("replay is too long")
+	}
+
+	campaign, found := s.Campaigns[replay.Campaign]
+	if !found {
+		return fmt.Errorf("unknown campaign %q", replay.Campaign)
+	}
+	if len(replay.Answers) > len(campaign.SpecialDataPoints)*sim.LevelQuizQuestions+sim.FinalQuizQuestions {
+		return errors.New("replay has too many quiz answers")
+	}
+
+	result := sim.Resimulate(campaign.SpecialDataPoints, campaign.Layouts, campaign.Behaviors, campaign.Regulator, replay)
+	if result.State != sim.GameOverState && result.State != sim.GoalState {
+		return errors.New("replay does not end the run")
+	}
+	if result.Score != submission.Score || result.Level != submission.Level || result.Acquisitions != submission.Acquisitions {
+		return errors.New("replay does not match the submitted score")
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
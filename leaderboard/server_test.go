@@ -0,0 +1,99 @@
+package leaderboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/content"
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/sim/simtest"
+)
+
+// Start a server of the embedded Google campaign and submit a run of its bot
+func playSubmission(t *testing.T) (*Server, Submission) {
+	t.Helper()
+	campaign := simtest.LoadCampaign(t, "google")
+	store, err := OpenFileStore(filepath.Join(t.TempDir(), "leaderboard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(store, []*content.Campaign{campaign})
+
+	s := simtest.NewSimulation(campaign, 7)
+	simtest.Play(s, MaxSteps, nil)
+	if s.Score <= 0 {
+		t.Fatalf("expected the run to score, ended in state %d after %d steps", s.State, s.Steps)
+	}
+
+	return server, Submission{
+		Initials:     "AAA",
+		Score:        s.Score,
+		Level:        s.Level,
+		Acquisitions: s.Acquisitions,
+		Replay:       s.Log(),
+	}
+}
+
+// Post submission to server, returning the status of the response
+func post(t *testing.T, server *Server, submission Submission) int {
+	t.Helper()
+	body, err := json.Marshal(submission)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/scores", bytes.NewReader(body)))
+	return recorder.Code
+}
+
+func TestVerifyAcceptsGenuineRun(t *testing.T) {
+	server, submission := playSubmission(t)
+	if err := server.Verify(submission); err != nil {
+		t.Fatalf("genuine run rejected: %v", err)
+	}
+}
+
+func TestVerifyRejectsTamperedScore(t *testing.T) {
+	server, submission := playSubmission(t)
+	submission.Score++
+	if err := server.Verify(submission); err == nil {
+		t.Fatal("tampered score accepted")
+	}
+}
+
+func TestSubmitRejectsResubmittedRun(t *testing.T) {
+	server, submission := playSubmission(t)
+	if status := post(t, server, submission); status != http.StatusCreated {
+		t.Fatalf("expected the first submission to be created, got status %d", status)
+	}
+	submission.Initials = "BBB"
+	if status := post(t, server, submission); status != http.StatusConflict {
+		t.Fatalf("expected the resubmission to conflict, got status %d", status)
+	}
+	if entries := server.Store.Top(10); len(entries) != 1 {
+		t.Fatalf("expected a single entry, got %d", len(entries))
+	}
+}
+
+func TestSubmitTurnsAwaySubmissionsOverTheVerificationLimit(t *testing.T) {
+	server, submission := playSubmission(t)
+	for i := 0; i < MaxVerifying; i++ {
+		server.verifying <- struct{}{}
+	}
+	if status := post(t, server, submission); status != http.StatusServiceUnavailable {
+		t.Fatalf("expected the submission to be turned away, got status %d", status)
+	}
+}
+
+func TestRunIDTellsRunsApart(t *testing.T) {
+	log := sim.RunLog{Campaign: "google", Seed: 1, Steps: 10}
+	other := log
+	other.Borderless = true
+	if RunID(log) != RunID(log) || RunID(log) == RunID(other) {
+		t.Fatal("expected the run ID to depend on the run only")
+	}
+}
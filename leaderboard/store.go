@@ -0,0 +1,126 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Error returned when adding the entry of a run already on the leaderboard
+var ErrDuplicateRun = errors.New("run already submitted")
+
+// Define a leaderboard kept in a single JSON file
+type FileStore struct {
+	path    string
+	mu      sync.Mutex
+	entries []Entry // Best score first
+}
+
+// Open the store at path, starting empty if the file does not exist
+func OpenFileStore(path string) (*FileStore, error) {
+	store := &FileStore{path: path}
+
+	fileData, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(fileData, &store.entries); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(store.entries, func(i, j int) bool { return store.entries[i].Score > store.entries[j].Score })
+	return store, nil
+}
+
+// Add an entry and write the store, returning its overall rank and its rank on the board of its seed
+func (f *FileStore) Add(entry Entry) (SubmitResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if entry.Run != "" && f.hasRun(entry.Run) {
+		return SubmitResult{}, ErrDuplicateRun
+	}
+
+	// Ties rank below the existing entries
+	rank := sort.Search(len(f.entries), func(i int) bool { return f.entries[i].Score < entry.Score })
+	entries := make([]Entry, 0, len(f.entries)+1)
+	entries = append(entries, f.entries[:rank]...)
+	entries = append(entries, entry)
+	entries = append(entries, f.entries[rank:]...)
+
+	if err := f.write(entries); err != nil {
+		return SubmitResult{}, err
+	}
+	f.entries = entries
+
+	result := SubmitResult{Rank: rank}
+	for _, other := range entries[:rank] {
+		if other.SeedBoard() == entry.SeedBoard() {
+			result.SeedRank++
+		}
+	}
+	return result, nil
+}
+
+// Check if the run with the given RunID is already on the leaderboard
+func (f *FileStore) HasRun(run string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.hasRun(run)
+}
+
+func (f *FileStore) hasRun(run string) bool {
+	for _, entry := range f.entries {
+		if entry.Run == run {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the n best entries
+func (f *FileStore) Top(n int) []Entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if n > len(f.entries) {
+		n = len(f.entries)
+	}
+	return append([]Entry{}, f.entries[:n]...)
+}
+
+// Return the n best entries of board
+func (f *FileStore) TopForSeed(board SeedBoard, n int) []Entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries := []Entry{}
+	for _, entry := range f.entries {
+		if len(entries) == n {
+			break
+		}
+		if entry.SeedBoard() == board {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Replace the file through a temporary one, so a crash never leaves it half written
+func (f *FileStore) write(entries []Entry) error {
+	fileData, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := f.path + ".tmp"
+	if err := os.WriteFile(tmpPath, fileData, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, f.path)
+}
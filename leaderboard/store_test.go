@@ -0,0 +1,36 @@
+package leaderboard
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+func TestSeedBoardsKeepCampaignsAndDifficultiesApart(t *testing.T) {
+	store, err := OpenFileStore(filepath.Join(t.TempDir(), "leaderboard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := func(score int64, campaign, difficulty string) Entry {
+		return Entry{HighScore: sim.HighScore{Initials: "AAA", Campaign: campaign, Score: score, Seed: 42}, Difficulty: difficulty}
+	}
+	for _, e := range []Entry{entry(300, "google", "nokia"), entry(200, "meta", "normal"), entry(100, "google", "normal")} {
+		if _, err := store.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := store.Add(entry(50, "google", "normal"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Rank != 3 || result.SeedRank != 1 {
+		t.Fatalf("expected rank 3 and seed rank 1, got %+v", result)
+	}
+
+	board := SeedBoard{Seed: 42, Campaign: "google", Difficulty: "normal"}
+	if entries := store.TopForSeed(board, 10); len(entries) != 2 || entries[0].Score != 100 || entries[1].Score != 50 {
+		t.Fatalf("unexpected entries on the board: %+v", entries)
+	}
+}
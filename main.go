@@ -1,26 +1,64 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/szkjn/snakeopoly-go/game"
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+var (
+	seed       = flag.Int64("seed", 0, "seed of the data point placement (0 draws a new one every run)")
+	record     = flag.String("record", "", "record the inputs of the session to this replay file")
+	replay     = flag.String("replay", "", "play back this replay file instead of reading the keyboard")
+	difficulty = flag.String("difficulty", "normal", "difficulty preset: easy, normal or nokia")
+	board      = flag.String("leaderboard", "", "URL of a leaderboard server to submit finished runs to")
+	contentDir = flag.String("content", "", "campaign dir (manifest, CSV and icons), or dir of campaign dirs, to play instead of the embedded ones")
+	campaign   = flag.String("campaign", game.DefaultCampaign, "ID of the campaign selected on start")
+	lang       = flag.String("lang", "", "language of the texts: en, fr or de (defaults to the last one picked)")
+	borderless = flag.Bool("borderless", false, "wrap the snake to the opposite edge instead of ending the run on the border")
+	arena      = flag.String("arena", "", "arena file edited from the welcome page (defaults to arena.txt in the user config dir)")
+	quiz       = flag.Bool("quiz", false, "ask questions about the acquisitions between levels and once the run is over")
 )
 
 func runGame() error {
 	ebiten.SetWindowSize(int(game.ScreenWidth), int(game.ScreenHeight))
 	ebiten.SetWindowTitle("The Snakeopoly")
+	ebiten.SetTPS(game.TPS)
+	ebiten.SetWindowClosingHandled(true)
+
+	preset, err := sim.ParseDifficulty(*difficulty)
+	if err != nil {
+		return err
+	}
 
-	g := game.NewGame()
+	opts := game.Options{Seed: *seed, Difficulty: preset, Record: *record != "", Leaderboard: *board, Content: *contentDir, Campaign: *campaign, Language: *lang, Borderless: *borderless, Quiz: *quiz, Arena: *arena}
+	if *replay != "" {
+		r, err := sim.LoadReplay(*replay)
+		if err != nil {
+			return err
+		}
+		opts.Replay = r
+	}
+
+	g := game.NewGame(opts)
 
 	if err := ebiten.RunGame(g); err != nil {
 		return err
 	}
 
+	if g.Recording != nil {
+		return g.Recording.Save(*record)
+	}
+
 	return nil
 }
 
 func main() {
+	flag.Parse()
+
 	if err := runGame(); err != nil {
 		log.Fatal(err)
 	}
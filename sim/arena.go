@@ -0,0 +1,140 @@
+package sim
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Characters of the arena text grid, the spawn being drawn as the head pointing to its direction
+const (
+	ArenaWall  = '#'
+	ArenaFree  = '.'
+	spawnChars = "^v<>" // Indexed by Direction
+)
+
+// Define a custom arena, its walls replacing those of every level
+type Arena struct {
+	Walls Layout
+	Spawn Spawn
+}
+
+// Return an arena without walls, the snake spawning at DefaultSpawn
+func NewArena() Arena {
+	return Arena{Walls: Layout{}, Spawn: DefaultSpawn}
+}
+
+// Check that the snake spawns inside the play area, off the walls and with free cells ahead, with room left for data points
+func (a Arena) Check() error {
+	for _, segment := range NewSnake(a.Spawn).Body {
+		if segment[0] < GridX1 || segment[0] >= GridX2 || segment[1] < GridY1 || segment[1] >= GridY2 {
+			return errors.New("the snake spawns outside of the play area")
+		}
+		if a.Walls.IsWall(segment[0], segment[1]) {
+			return errors.New("the snake spawns on a wall")
+		}
+	}
+
+	// The cells right ahead of the spawn stay free, as they do when walls are raised
+	moveX, moveY := a.Spawn.Dir.Vector()
+	for i := 1; i <= WallGraceCells; i++ {
+		x, y := a.Spawn.X+float32(i*moveX), a.Spawn.Y+float32(i*moveY)
+		if x < GridX1 || x >= GridX2 || y < GridY1 || y >= GridY2 {
+			return errors.New("the snake spawns facing the border")
+		}
+		if a.Walls.IsWall(x, y) {
+			return errors.New("the snake spawns facing a wall")
+		}
+	}
+	if len(a.Walls)+int(InitialSnakeLength) >= LayoutWidth*LayoutHeight {
+		return errors.New("no room left for data points")
+	}
+	return nil
+}
+
+// Encode the arena as a text grid of LayoutWidth by LayoutHeight cells
+func (a Arena) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for y := GridY1; y < GridY2; y++ {
+		for x := GridX1; x < GridX2; x++ {
+			if x == a.Spawn.X && y == a.Spawn.Y {
+				bw.WriteByte(spawnChars[a.Spawn.Dir])
+			} else if a.Walls.IsWall(x, y) {
+				bw.WriteByte(ArenaWall)
+			} else {
+				bw.WriteByte(ArenaFree)
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// Decode an arena written by Write, spawning the snake at DefaultSpawn if the grid has no spawn
+func ReadArena(r io.Reader) (Arena, error) {
+	arena := NewArena()
+	scanner := bufio.NewScanner(r)
+	spawns := 0
+	rows := 0
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if rows == LayoutHeight {
+			if line != "" {
+				return arena, fmt.Errorf("arena: expected %d rows", LayoutHeight)
+			}
+			continue
+		}
+		if len(line) != LayoutWidth {
+			return arena, fmt.Errorf("arena: row %d: expected %d columns, got %d", rows+1, LayoutWidth, len(line))
+		}
+		for i, char := range line {
+			x, y := GridX1+float32(i), GridY1+float32(rows)
+			if char == ArenaWall {
+				arena.Walls[[2]float32{x, y}] = true
+			} else if dir := strings.IndexRune(spawnChars, char); dir >= 0 {
+				arena.Spawn = Spawn{X: x, Y: y, Dir: Direction(dir)}
+				spawns++
+			} else if char != ArenaFree {
+				return arena, fmt.Errorf("arena: row %d: unexpected %q", rows+1, char)
+			}
+		}
+		rows++
+	}
+	if err := scanner.Err(); err != nil {
+		return arena, err
+	}
+	if rows != LayoutHeight {
+		return arena, fmt.Errorf("arena: expected %d rows, got %d", LayoutHeight, rows)
+	}
+	if spawns > 1 {
+		return arena, fmt.Errorf("arena: %d spawns, expected at most 1", spawns)
+	}
+	return arena, arena.Check()
+}
+
+// Write the arena to the file at path
+func (a Arena) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := a.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Read the arena from the file at path
+func LoadArena(path string) (Arena, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Arena{}, err
+	}
+	defer file.Close()
+
+	return ReadArena(file)
+}
@@ -0,0 +1,87 @@
+package sim_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+func TestArenaWriteReadRoundTrip(t *testing.T) {
+	arena := sim.NewArena()
+	arena.Spawn = sim.Spawn{X: sim.GridX1 + 10, Y: sim.GridY1 + 5, Dir: sim.DirUp}
+	arena.Walls[[2]float32{sim.GridX1, sim.GridY1}] = true
+	arena.Walls[[2]float32{sim.GridX2 - 1, sim.GridY2 - 1}] = true
+
+	var buf bytes.Buffer
+	if err := arena.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	read, err := sim.ReadArena(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, arena) {
+		t.Errorf("expected %+v, got %+v", arena, read)
+	}
+}
+
+func TestReadArenaWithoutSpawnUsesTheDefaultOne(t *testing.T) {
+	grid := strings.Repeat(strings.Repeat(".", sim.LayoutWidth)+"\n", sim.LayoutHeight)
+	arena, err := sim.ReadArena(strings.NewReader(grid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if arena.Spawn != sim.DefaultSpawn || len(arena.Walls) != 0 {
+		t.Errorf("expected an empty arena spawning at the default spawn, got %+v", arena)
+	}
+}
+
+func TestReadArenaRejectsMalformedGrids(t *testing.T) {
+	row := strings.Repeat(".", sim.LayoutWidth) + "\n"
+	tests := map[string]string{
+		"short row":    strings.Repeat(row, sim.LayoutHeight-1) + "...\n",
+		"missing row":  strings.Repeat(row, sim.LayoutHeight-1),
+		"extra row":    strings.Repeat(row, sim.LayoutHeight+1),
+		"unknown cell": strings.Repeat(row, sim.LayoutHeight-1) + "x" + row[1:],
+		"two spawns":   ">" + row[1:] + strings.Repeat(row, sim.LayoutHeight-2) + ">" + row[1:],
+	}
+	for name, grid := range tests {
+		if _, err := sim.ReadArena(strings.NewReader(grid)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestArenaCheck(t *testing.T) {
+	spawn := sim.Spawn{X: sim.GridX1 + 10, Y: sim.GridY1 + 7, Dir: sim.DirRight}
+	tests := []struct {
+		name  string
+		spawn sim.Spawn
+		wall  [2]float32 // Cell of the only wall, none if zero
+		valid bool
+	}{
+		{name: "free", spawn: spawn, valid: true},
+		{name: "wall behind the grace cells", spawn: spawn, wall: [2]float32{spawn.X + sim.WallGraceCells + 1, spawn.Y}, valid: true},
+		{name: "body out of the grid", spawn: sim.Spawn{X: sim.GridX1, Y: spawn.Y, Dir: sim.DirRight}},
+		{name: "wall on the body", spawn: spawn, wall: [2]float32{spawn.X - 1, spawn.Y}},
+		{name: "wall right ahead", spawn: spawn, wall: [2]float32{spawn.X + 1, spawn.Y}},
+		{name: "wall at the last grace cell", spawn: spawn, wall: [2]float32{spawn.X + sim.WallGraceCells, spawn.Y}},
+		{name: "facing the border", spawn: sim.Spawn{X: sim.GridX2 - 2, Y: spawn.Y, Dir: sim.DirRight}},
+		{name: "facing the top border", spawn: sim.Spawn{X: spawn.X, Y: sim.GridY1 + 1, Dir: sim.DirUp}},
+	}
+	for _, test := range tests {
+		arena := sim.Arena{Walls: sim.Layout{}, Spawn: test.spawn}
+		if test.wall != [2]float32{} {
+			arena.Walls[test.wall] = true
+		}
+		if err := arena.Check(); (err == nil) != test.valid {
+			t.Errorf("%s: expected valid %v, got %v", test.name, test.valid, err)
+		}
+	}
+	if err := sim.NewArena().Check(); err != nil {
+		t.Errorf("expected the default arena to be valid, got %v", err)
+	}
+}
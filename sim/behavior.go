@@ -0,0 +1,95 @@
+package sim
+
+// Define how many data points a level has on the board and how its regular ones behave
+type Behavior struct {
+	Count      int // Data points on the board at once, 0 for 1
+	Lifetime   int // Moves a data point lasts before showing up elsewhere, 0 for ever
+	FleePeriod int // Moves of the snake per cell a data point flees from the head, 0 to stay put
+}
+
+// Define the behavior of the regular data points by level name, levels without one keeping them still
+type Behaviors map[string]Behavior
+
+// Define a regular data point of a level with a behavior, expiring or fleeing the snake
+type FleetingDataPoint struct {
+	DataPoint
+	Age int // Moves since it showed up
+}
+
+func (b Behavior) count() int {
+	if b.Count > 0 {
+		return b.Count
+	}
+	return 1
+}
+
+// Return the behavior of the data points of the current level
+func (s *Simulation) Behavior() Behavior {
+	return s.behaviors[s.Level]
+}
+
+// Return the moves left before dp expires, or -1 if it lasts for ever
+func (s *Simulation) MovesLeft(dp DataPointInterface) int {
+	fleeting, isFleeting := dp.(FleetingDataPoint)
+	if !isFleeting || s.Behavior().Lifetime == 0 {
+		return -1
+	}
+	return s.Behavior().Lifetime - fleeting.Age
+}
+
+// Create a regular data point at a free random position, fleeting if the current level has it expire or flee
+func (s *Simulation) newRegularDataPoint() DataPointInterface {
+	dp := NewDataPoint(s.Snake, s.occupied(), s.rng)
+	if behavior := s.Behavior(); behavior.Lifetime == 0 && behavior.FleePeriod == 0 {
+		return dp
+	}
+	return FleetingDataPoint{DataPoint: dp}
+}
+
+// Age the data points on the board, replacing the expired ones and moving the others away from the head
+func (s *Simulation) updateDataPoints(events *Events) {
+	if s.State != PlayState {
+		return
+	}
+
+	behavior := s.Behavior()
+	for i, dp := range s.DataPoints {
+		fleeting, isFleeting := dp.(FleetingDataPoint)
+
+		// The head reached it, it is collected on the next move
+		if !isFleeting || fleeting.IsColliding(s.Snake) {
+			continue
+		}
+
+		fleeting.Age++
+		if behavior.Lifetime > 0 && fleeting.Age >= behavior.Lifetime {
+			s.DataPoints[i] = s.newRegularDataPoint()
+			events.Expired = true
+			continue
+		}
+		if behavior.FleePeriod > 0 && fleeting.Age%behavior.FleePeriod == 0 {
+			fleeting.X, fleeting.Y = s.flee(fleeting.X, fleeting.Y)
+		}
+		s.DataPoints[i] = fleeting
+	}
+}
+
+// Return the cell next to x, y farthest from the head of the snake, staying put unless it gets farther
+func (s *Simulation) flee(x, y float32) (float32, float32) {
+	distances := s.distancesToHead()
+	occupied := s.occupied()
+	best := [2]float32{x, y}
+	bestDistance, reachable := distances[best]
+	if !reachable {
+		return x, y
+	}
+	for _, next := range s.neighbours(best) {
+		if occupied[next] {
+			continue
+		}
+		if distance, reachable := distances[next]; reachable && distance > bestDistance {
+			best, bestDistance = next, distance
+		}
+	}
+	return best[0], best[1]
+}
@@ -0,0 +1,116 @@
+package sim_test
+
+import (
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Return a borderless simulation at a level of behavior, with a single fleeing data point at x, y
+func behaviorSimulation(behavior sim.Behavior, x, y float32) *sim.Simulation {
+	specials := []sim.SpecialDataPoint{{Name: "First", Slug: "first", Level: "Search"}}
+	s := sim.NewSimulation(specials, nil, sim.Behaviors{"Search": behavior}, sim.Regulation{}, 1, sim.Normal)
+	s.Borderless = true
+	s.DataPoints = []sim.DataPointInterface{sim.FleetingDataPoint{DataPoint: sim.DataPoint{X: x, Y: y}}}
+	return s
+}
+
+func TestDataPointsExpireAfterTheirLifetime(t *testing.T) {
+	s := behaviorSimulation(sim.Behavior{Lifetime: 5}, sim.GridX1, sim.GridY1)
+	for move := 1; move < 5; move++ {
+		if events := s.Step(sim.DirNone); events.Expired {
+			t.Fatalf("expected the data point to last 5 moves, expired after %d", move)
+		}
+		if left := s.MovesLeft(s.DataPoints[0]); left != 5-move {
+			t.Fatalf("move %d: expected %d moves left, got %d", move, 5-move, left)
+		}
+	}
+	if events := s.Step(sim.DirNone); !events.Expired {
+		t.Fatal("expected the data point to expire after 5 moves")
+	}
+	if fleeting, ok := s.DataPoints[0].(sim.FleetingDataPoint); !ok || fleeting.Age != 0 || len(s.DataPoints) != 1 {
+		t.Errorf("expected a single new fleeting data point, got %+v", s.DataPoints)
+	}
+}
+
+func TestStillDataPointsLastForEver(t *testing.T) {
+	s := sim.NewSimulation(nil, nil, nil, sim.Regulation{}, 1, sim.Normal)
+	s.Borderless = true
+	s.DataPoints = []sim.DataPointInterface{sim.DataPoint{X: sim.GridX1, Y: sim.GridY1}}
+	for move := 0; move < 100; move++ {
+		if events := s.Step(sim.DirNone); events.Expired {
+			t.Fatalf("expected a data point without behavior to stay, expired after %d moves", move+1)
+		}
+	}
+	if left := s.MovesLeft(s.DataPoints[0]); left != -1 {
+		t.Errorf("expected no moves left count, got %d", left)
+	}
+	if x, y := s.DataPoints[0].Position(); x != sim.GridX1 || y != sim.GridY1 {
+		t.Errorf("expected the data point to stay put, moved to %v, %v", x, y)
+	}
+}
+
+func TestDataPointsFleeTheHead(t *testing.T) {
+	// Put the data point a few cells ahead of the head, chased in a straight line
+	s := sim.NewSimulation(nil, nil, nil, sim.Regulation{}, 1, sim.Normal)
+	moveX, moveY := s.CurrentDir.Vector()
+	headX, headY := s.Snake.Body[0][0], s.Snake.Body[0][1]
+	s = behaviorSimulation(sim.Behavior{FleePeriod: 1}, headX+4*float32(moveX), headY+4*float32(moveY))
+
+	distance := func() float32 {
+		x, y := s.DataPoints[0].Position()
+		return abs(x-s.Snake.Body[0][0]) + abs(y-s.Snake.Body[0][1])
+	}
+	for move := 0; move < 3; move++ {
+		before := distance()
+		if events := s.Step(sim.DirNone); events.Collected || events.GameOver {
+			t.Fatalf("move %d: expected the data point to keep away, got %+v", move+1, events)
+		}
+		if after := distance(); after < before {
+			t.Fatalf("move %d: expected the data point to keep its distance of %v, got %v", move+1, before, after)
+		}
+	}
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func TestSpecialsKeepComingAfterTheCountDrops(t *testing.T) {
+	specials := []sim.SpecialDataPoint{
+		{Name: "First", Slug: "first", Level: "Search"},
+		{Name: "Second", Slug: "second", Level: "Privacy"},
+		{Name: "Third", Slug: "third", Level: "Privacy"},
+	}
+	s := sim.NewSimulation(specials, nil, sim.Behaviors{"Search": {Count: 3}}, sim.Regulation{}, 1, sim.Normal)
+	s.Borderless = true
+
+	// The level changed to one with a single data point while three regular ones are still on the board
+	headX, headY := s.Snake.Body[0][0], s.Snake.Body[0][1]
+	s.Level = "Privacy"
+	s.SpecialDataPoints = specials[2:]
+	s.DataPoints = []sim.DataPointInterface{
+		sim.DataPoint{X: headX, Y: headY},
+		sim.DataPoint{X: sim.GridX1, Y: sim.GridY1},
+		sim.DataPoint{X: sim.GridX1 + 1, Y: sim.GridY1},
+	}
+	s.Pickups = sim.SpecialDataPointsRate - 1
+
+	if events := s.Step(sim.DirNone); !events.Collected {
+		t.Fatalf("expected the data point under the head to be collected, got %+v", events)
+	}
+	if len(s.DataPoints) != 2 {
+		t.Fatalf("expected the board to keep 2 data points, got %d", len(s.DataPoints))
+	}
+	found := false
+	for _, dp := range s.DataPoints {
+		special, isSpecial := dp.(sim.SpecialDataPoint)
+		found = found || isSpecial && special.Slug == "third"
+	}
+	if !found {
+		t.Errorf("expected the next special data point on the board, got %+v", s.DataPoints)
+	}
+}
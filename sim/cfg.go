@@ -0,0 +1,41 @@
+package sim
+
+// Constants related to the play area, expressed in grid cells
+const (
+	GridX1 float32 = 1
+	GridY1 float32 = 1
+	GridX2 float32 = 24
+	GridY2 float32 = 16
+)
+
+// Constants related to the snake and data points
+const (
+	InitialSnakeLength    float32 = 3
+	SpecialDataPointsRate int     = 3 // Every SpecialDataPointsRate pickups, the next data point is special
+	PowerUpRate           int     = 5 // Every PowerUpRate pickups, the next data point is a power-up unless it is special
+	MaxDataPoints         int     = 8 // Most data points a level may have on the board at once
+)
+
+// Constants related to scoring
+const (
+	RegularDataPointValue int64 = 10 // Points of a regular data point
+	SpecialDataPointValue int64 = 50 // Points of a special data point without its own value
+	StreakWindow          int   = 30 // Moves allowed between pickups to keep a streak going
+	MaxStreakMultiplier   int64 = 5
+	SpeedBonusWindow      int   = 15 // Pickups reached in fewer moves earn a point per move saved
+)
+
+// Constants related to the antitrust regulator
+const (
+	RegulatorPeriod        int     = 2  // Moves of the snake per move of the regulator, unless the campaign sets its own
+	RegulatorSpawnDistance float32 = 8  // Cells kept between the head of the snake and a regulator showing up
+	RegulatorSpawnTries    int     = 20 // Positions drawn to find one far enough from the head
+)
+
+// Constants related to the quiz mode
+const (
+	QuizChoices        int   = 4   // Choices offered per question, when there are enough entries
+	QuizPoints         int64 = 100 // Points of a right answer
+	LevelQuizQuestions int   = 2   // Questions asked when the level changes
+	FinalQuizQuestions int   = 3   // Questions asked once the run is over
+)
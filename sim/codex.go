@@ -0,0 +1,34 @@
+package sim
+
+// Define the special data points unlocked across all runs, their slugs by campaign ID
+type Codex map[string][]string
+
+// Check if the special data point of slug has been unlocked in campaign
+func (c Codex) Unlocked(campaign, slug string) bool {
+	for _, unlocked := range c[campaign] {
+		if unlocked == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// Unlock the special data point of slug in campaign, returning false if it already was
+func (c Codex) Unlock(campaign, slug string) bool {
+	if c.Unlocked(campaign, slug) {
+		return false
+	}
+	c[campaign] = append(c[campaign], slug)
+	return true
+}
+
+// Count the special data points of specialDataPoints unlocked in campaign
+func (c Codex) Count(campaign string, specialDataPoints []SpecialDataPoint) int {
+	count := 0
+	for _, special := range specialDataPoints {
+		if c.Unlocked(campaign, special.Slug) {
+			count++
+		}
+	}
+	return count
+}
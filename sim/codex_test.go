@@ -0,0 +1,37 @@
+package sim_test
+
+import (
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+func TestCodexUnlocksEachSlugOncePerCampaign(t *testing.T) {
+	codex := sim.Codex{}
+	if !codex.Unlock("google", "keyhole") {
+		t.Fatal("expected the first unlock to be new")
+	}
+	if codex.Unlock("google", "keyhole") {
+		t.Error("expected the second unlock of the same slug not to be new")
+	}
+	if !codex.Unlock("meta", "keyhole") {
+		t.Error("expected the same slug in another campaign to be new")
+	}
+	if len(codex["google"]) != 1 {
+		t.Errorf("expected 1 unlocked slug in google, got %v", codex["google"])
+	}
+	if codex.Unlocked("google", "appsem") {
+		t.Error("expected a slug never unlocked to stay locked")
+	}
+}
+
+func TestCodexCountsTheUnlockedSpecialsOfACampaign(t *testing.T) {
+	codex := sim.Codex{"google": {"appsem", "keyhole"}, "meta": {"youtube"}}
+	specials := []sim.SpecialDataPoint{{Slug: "appsem"}, {Slug: "keyhole"}, {Slug: "youtube"}}
+	if got := codex.Count("google", specials); got != 2 {
+		t.Errorf("expected 2 unlocked in google, got %d", got)
+	}
+	if got := codex.Count("amazon", specials); got != 0 {
+		t.Errorf("expected none unlocked in a campaign never played, got %d", got)
+	}
+}
@@ -0,0 +1,262 @@
+package sim
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Define common methods for all data points
+type DataPointInterface interface {
+	Position() (float32, float32)
+	IsColliding(snake Snake) bool
+	OnCollect(s *Simulation, events *Events) // Apply what collecting the data point does, once scored
+}
+
+// Define a regular data point in the game
+type DataPoint struct {
+	X, Y float32
+}
+
+// Define a special data point in the game
+type SpecialDataPoint struct {
+	DataPoint
+	Name       string
+	Slug       string
+	Year       int
+	Text       string // Quote of the villain
+	Level      string
+	Growth     int               // Cells grown when collected, 0 to use the difficulty default
+	Value      int64             // Points when collected, 0 to use SpecialDataPointValue
+	Price      string            // Acquisition price as written in the CSV, empty if unknown
+	Category   string            // Sector of the acquired company
+	Summary    string            // Factual one-line summary of the acquisition
+	Sources    []string          // Citations backing the summary
+	Texts      map[string]string // Translations of Text by language, from the text_<lang> columns
+	Levels     map[string]string // Translations of Level by language, from the level_<lang> columns
+	Summaries  map[string]string // Translations of Summary by language, from the summary_<lang> columns
+	Categories map[string]string // Translations of Category by language, from the category_<lang> columns
+}
+
+// Columns every special data points CSV has, found by header name
+var RequiredColumns = []string{"name", "slug", "year", "text", "level"}
+
+// Separator of the citations of the sources column
+const SourcesSeparator = "|"
+
+// Return the text in lang, or the untranslated one
+func (s SpecialDataPoint) LocalizedText(lang string) string {
+	return localized(s.Texts, lang, s.Text)
+}
+
+// Return the summary in lang, or the untranslated one
+func (s SpecialDataPoint) LocalizedSummary(lang string) string {
+	return localized(s.Summaries, lang, s.Summary)
+}
+
+// Return the category in lang, or the untranslated one
+func (s SpecialDataPoint) LocalizedCategory(lang string) string {
+	return localized(s.Categories, lang, s.Category)
+}
+
+func localized(translations map[string]string, lang, text string) string {
+	if translation := translations[lang]; translation != "" {
+		return translation
+	}
+	return text
+}
+
+// Return xy coordinates of the DataPoint
+func (d DataPoint) Position() (float32, float32) {
+	return d.X, d.Y
+}
+
+// Grow the snake by the cells of a regular data point
+func (d DataPoint) OnCollect(s *Simulation, events *Events) {
+	s.Snake.PendingGrowth += s.Rules.DataPointGrowth
+}
+
+// Acquire the special data point, changing the level and ending the run on the last one
+func (special SpecialDataPoint) OnCollect(s *Simulation, events *Events) {
+	growth := special.Growth
+	if growth == 0 {
+		growth = s.Rules.SpecialGrowth
+	}
+	s.State = SpecialState // Trigger special state on collision with special data point
+	s.CurrentSpecialDataPoint = special
+	s.Acquisitions++
+	s.Acquired = append(s.Acquired, Acquisition{Slug: special.Slug, Step: s.Steps, Time: s.PlayTime})
+	s.buildTimeline()
+	events.Special = true
+	events.LevelChanged = s.Level != special.Level
+	s.Level = special.Level
+	s.quizDue = events.LevelChanged
+	if events.LevelChanged {
+		s.LevelChanges++
+		s.buildWalls()
+	}
+
+	// Check if this is the last special data point
+	if s.LastSpecialDataPoint {
+		s.State = GoalState
+		s.startQuiz(FinalQuizQuestions, GoalState)
+		events.Goal = true
+	}
+
+	// Grow over the next moves
+	s.Snake.PendingGrowth += growth
+}
+
+// Check collision with Snake
+func (d DataPoint) IsColliding(snake Snake) bool {
+	headX, headY := snake.Body[0][0], snake.Body[0][1]
+	return headX >= d.X && headX < d.X+1 && headY >= d.Y && headY < d.Y+1
+}
+
+// Parse special data points from CSV records, finding every column by the name in the header row
+func ParseSpecialDataPoints(records [][]string) []SpecialDataPoint {
+	header := records[0]
+	var specialDataPoints []SpecialDataPoint
+	for _, record := range records[1:] {
+		var specialDataPoint SpecialDataPoint
+		for i := 0; i < len(record) && i < len(header); i++ {
+			specialDataPoint.setColumn(strings.ToLower(strings.TrimSpace(header[i])), record[i])
+		}
+		specialDataPoints = append(specialDataPoints, specialDataPoint)
+	}
+
+	return specialDataPoints
+}
+
+// Set the field of column to value, ignoring unknown columns
+func (s *SpecialDataPoint) setColumn(column, value string) {
+	switch column {
+	case "name":
+		s.Name = value
+	case "slug":
+		s.Slug = value
+	case "year":
+		s.Year, _ = strconv.Atoi(value)
+	case "text":
+		s.Text = value
+	case "level":
+		s.Level = value
+	case "growth":
+		s.Growth, _ = strconv.Atoi(value)
+	case "value":
+		s.Value, _ = strconv.ParseInt(value, 10, 64)
+	case "price":
+		s.Price = value
+	case "category":
+		s.Category = value
+	case "summary":
+		s.Summary = value
+	case "sources":
+		for _, source := range strings.Split(value, SourcesSeparator) {
+			if source = strings.TrimSpace(source); source != "" {
+				s.Sources = append(s.Sources, source)
+			}
+		}
+	default:
+		if value == "" {
+			return
+		}
+		if lang, found := strings.CutPrefix(column, "text_"); found {
+			s.Texts = setTranslation(s.Texts, lang, value)
+		} else if lang, found := strings.CutPrefix(column, "level_"); found {
+			s.Levels = setTranslation(s.Levels, lang, value)
+		} else if lang, found := strings.CutPrefix(column, "summary_"); found {
+			s.Summaries = setTranslation(s.Summaries, lang, value)
+		} else if lang, found := strings.CutPrefix(column, "category_"); found {
+			s.Categories = setTranslation(s.Categories, lang, value)
+		}
+	}
+}
+
+func setTranslation(translations map[string]string, lang, text string) map[string]string {
+	if translations == nil {
+		translations = map[string]string{}
+	}
+	translations[lang] = text
+	return translations
+}
+
+// Return the translation of level in lang, or level itself if none of specialDataPoints has one
+func LocalizedLevel(specialDataPoints []SpecialDataPoint, level, lang string) string {
+	for _, special := range specialDataPoints {
+		if special.Level == level && special.Levels[lang] != "" {
+			return special.Levels[lang]
+		}
+	}
+	return level
+}
+
+// Read special data points from a semicolon separated CSV file
+func ReadSpecialDataPoints(r io.Reader) ([]SpecialDataPoint, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("special data points: missing header")
+	}
+	for _, required := range RequiredColumns {
+		found := false
+		for _, column := range records[0] {
+			found = found || strings.EqualFold(strings.TrimSpace(column), required)
+		}
+		if !found {
+			return nil, fmt.Errorf("special data points: missing %s column", required)
+		}
+	}
+	return ParseSpecialDataPoints(records), nil
+}
+
+// Return a random cell of the play area free of the snake and of the blocked cells (walls and other data points)
+func GenerateRandomPosition(snake Snake, blocked Layout, rng *rand.Rand) [2]float32 {
+	// Calculate the available grid positions within the play area, around the blocked cells
+	availablePositions := []struct{ x, y int }{}
+	for x := int(GridX1); x < int(GridX2); x++ {
+		for y := int(GridY1); y < int(GridY2); y++ {
+			isColliding := blocked.IsWall(float32(x), float32(y))
+			for _, segment := range snake.Body {
+				if int(segment[0]) == x && int(segment[1]) == y {
+					isColliding = true
+					break
+				}
+			}
+			if !isColliding {
+				availablePositions = append(availablePositions, struct{ x, y int }{x, y})
+			}
+		}
+	}
+
+	// Randomly select one available position for the data point
+	if len(availablePositions) > 0 {
+		selected := availablePositions[rng.Intn(len(availablePositions))]
+		return [2]float32{float32(selected.x), float32(selected.y)}
+	}
+
+	// Return a default position if no available positions
+	return [2]float32{GridX1, GridY1}
+}
+
+// Create a new data point at a valid random position
+func NewDataPoint(snake Snake, blocked Layout, rng *rand.Rand) DataPoint {
+	position := GenerateRandomPosition(snake, blocked, rng)
+	return DataPoint{X: position[0], Y: position[1]}
+}
+
+// Create a new special data point at a valid random position
+func NewSpecialDataPoint(snake Snake, blocked Layout, special SpecialDataPoint, rng *rand.Rand) SpecialDataPoint {
+	// Generate a random position for this special data point
+	position := GenerateRandomPosition(snake, blocked, rng)
+	special.X = position[0]
+	special.Y = position[1]
+	return special
+}
@@ -0,0 +1,83 @@
+package sim_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+func TestLocalizedTextsFallBackToTheUntranslatedOnes(t *testing.T) {
+	special := sim.SpecialDataPoint{
+		Text:      "Mine now",
+		Summary:   "Bought",
+		Category:  "Search",
+		Texts:     map[string]string{"fr": "À moi"},
+		Summaries: map[string]string{"fr": ""},
+	}
+	if got := special.LocalizedText("fr"); got != "À moi" {
+		t.Errorf("expected the French text, got %q", got)
+	}
+	if got := special.LocalizedText("de"); got != "Mine now" {
+		t.Errorf("expected the untranslated text, got %q", got)
+	}
+	if got := special.LocalizedSummary("fr"); got != "Bought" {
+		t.Errorf("expected the untranslated summary for an empty translation, got %q", got)
+	}
+	if got := special.LocalizedCategory("fr"); got != "Search" {
+		t.Errorf("expected the untranslated category, got %q", got)
+	}
+}
+
+func TestLocalizedLevelUsesAnyRowTranslatingIt(t *testing.T) {
+	specials := []sim.SpecialDataPoint{
+		{Level: "Mogul"},
+		{Level: "Mogul", Levels: map[string]string{"fr": "Magnat"}},
+	}
+	if got := sim.LocalizedLevel(specials, "Mogul", "fr"); got != "Magnat" {
+		t.Errorf("expected the French level, got %q", got)
+	}
+	if got := sim.LocalizedLevel(specials, "Mogul", "de"); got != "Mogul" {
+		t.Errorf("expected the untranslated level, got %q", got)
+	}
+}
+
+func TestReadSpecialDataPointsFindsColumnsByName(t *testing.T) {
+	csv := "Level;Year;slug;text_fr;name;text;sources;text_de\n" +
+		"Mogul;2004;keyhole;À moi;Keyhole;Mine;Wikipedia | Press;\n"
+	specials, err := sim.ReadSpecialDataPoints(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sim.SpecialDataPoint{{
+		Name:    "Keyhole",
+		Slug:    "keyhole",
+		Year:    2004,
+		Text:    "Mine",
+		Level:   "Mogul",
+		Sources: []string{"Wikipedia", "Press"},
+		Texts:   map[string]string{"fr": "À moi"},
+	}}
+	if !reflect.DeepEqual(specials, want) {
+		t.Errorf("expected %+v, got %+v", want, specials)
+	}
+}
+
+func TestReadSpecialDataPointsRequiresColumns(t *testing.T) {
+	for _, required := range sim.RequiredColumns {
+		var columns []string
+		for _, column := range sim.RequiredColumns {
+			if column != required {
+				columns = append(columns, column)
+			}
+		}
+		_, err := sim.ReadSpecialDataPoints(strings.NewReader(strings.Join(columns, ";") + "\n"))
+		if err == nil || !strings.Contains(err.Error(), "missing "+required) {
+			t.Errorf("without %s: expected a missing column error, got %v", required, err)
+		}
+	}
+	if _, err := sim.ReadSpecialDataPoints(strings.NewReader("")); err == nil {
+		t.Error("expected an error for a CSV without header")
+	}
+}
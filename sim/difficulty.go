@@ -0,0 +1,51 @@
+package sim
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Difficulty presets selecting the rules of a game
+type Difficulty int
+
+const (
+	Normal Difficulty = iota
+	Easy
+	Nokia
+)
+
+var difficultyNames = []string{"normal", "easy", "nokia"}
+
+func (d Difficulty) String() string {
+	if d < 0 || int(d) >= len(difficultyNames) {
+		return fmt.Sprintf("Difficulty(%d)", int(d))
+	}
+	return difficultyNames[d]
+}
+
+// Return the difficulty with the given name
+func ParseDifficulty(name string) (Difficulty, error) {
+	for i, difficultyName := range difficultyNames {
+		if strings.EqualFold(name, difficultyName) {
+			return Difficulty(i), nil
+		}
+	}
+	return Normal, fmt.Errorf("unknown difficulty %q", name)
+}
+
+// Define the rules that vary with the difficulty
+type Rules struct {
+	DataPointGrowth int // Cells grown per regular data point
+	SpecialGrowth   int // Cells grown per special data point without its own growth
+}
+
+// Return the rules of the difficulty preset
+func (d Difficulty) Rules() Rules {
+	switch d {
+	case Easy:
+		return Rules{DataPointGrowth: 1, SpecialGrowth: 2}
+	case Nokia:
+		return Rules{DataPointGrowth: 3, SpecialGrowth: 6}
+	}
+	return Rules{DataPointGrowth: 2, SpecialGrowth: 4}
+}
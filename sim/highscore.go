@@ -0,0 +1,49 @@
+package sim
+
+import (
+	"sort"
+	"time"
+)
+
+// Number of entries kept in a high score table
+const MaxHighScores = 10
+
+// Define a single entry of the high score table
+type HighScore struct {
+	Initials     string
+	Campaign     string `json:",omitempty"`
+	Score        int64
+	Level        string // Level reached
+	Acquisitions int    // Special data points collected
+	Seed         int64
+	Date         time.Time
+}
+
+// Define a high score table, best score first
+type HighScores []HighScore
+
+// Check if a score would enter the table
+func (h HighScores) Qualifies(score int64) bool {
+	if score <= 0 {
+		return false
+	}
+	return len(h) < MaxHighScores || score > h[len(h)-1].Score
+}
+
+// Insert an entry, returning the new table and the rank of the entry (-1 if it did not qualify)
+func (h HighScores) Insert(entry HighScore) (HighScores, int) {
+	if !h.Qualifies(entry.Score) {
+		return h, -1
+	}
+
+	// Ties rank below the existing entries
+	rank := sort.Search(len(h), func(i int) bool { return h[i].Score < entry.Score })
+	table := make(HighScores, 0, len(h)+1)
+	table = append(table, h[:rank]...)
+	table = append(table, entry)
+	table = append(table, h[rank:]...)
+	if len(table) > MaxHighScores {
+		table = table[:MaxHighScores]
+	}
+	return table, rank
+}
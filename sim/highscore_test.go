@@ -0,0 +1,56 @@
+package sim_test
+
+import (
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Build a table of the given scores, initials telling their entries apart
+func table(scores ...int64) sim.HighScores {
+	var h sim.HighScores
+	for i, score := range scores {
+		h = append(h, sim.HighScore{Initials: string(rune('A' + i)), Score: score})
+	}
+	return h
+}
+
+func TestHighScoresInsert(t *testing.T) {
+	full := table(100, 90, 80, 70, 60, 50, 40, 30, 20, 10)
+	tests := []struct {
+		name       string
+		table      sim.HighScores
+		score      int64
+		wantRank   int
+		wantLength int
+	}{
+		{"empty table", nil, 10, 0, 1},
+		{"best score", table(30, 20), 40, 0, 3},
+		{"tie ranks below", table(30, 20, 10), 20, 2, 4},
+		{"tie with the last one", table(30, 20), 20, 2, 3},
+		{"full table drops the last entry", full, 55, 5, sim.MaxHighScores},
+		{"full table tie with the last one", full, 10, -1, sim.MaxHighScores},
+		{"full table below the last one", full, 5, -1, sim.MaxHighScores},
+		{"zero score", table(30), 0, -1, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entry := sim.HighScore{Initials: "NEW", Score: test.score}
+			got, rank := test.table.Insert(entry)
+			if rank != test.wantRank {
+				t.Fatalf("expected rank %d, got %d", test.wantRank, rank)
+			}
+			if len(got) != test.wantLength {
+				t.Fatalf("expected %d entries, got %d", test.wantLength, len(got))
+			}
+			if rank >= 0 && got[rank].Initials != "NEW" {
+				t.Fatalf("expected the new entry at rank %d, got %+v", rank, got[rank])
+			}
+			for i := 1; i < len(got); i++ {
+				if got[i].Score > got[i-1].Score {
+					t.Fatalf("table out of order at %d: %+v", i, got)
+				}
+			}
+		})
+	}
+}
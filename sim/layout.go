@@ -0,0 +1,95 @@
+package sim
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Size of a layout, in grid cells
+const (
+	LayoutWidth  = int(GridX2 - GridX1)
+	LayoutHeight = int(GridY2 - GridY1)
+)
+
+// Cells ahead of the head kept free of the walls of a new level, so the snake is not trapped on arrival
+const WallGraceCells = 3
+
+// Define the walls of a level, as the grid cells they fill
+type Layout map[[2]float32]bool
+
+// Define the layout of each level, by level name
+type Layouts map[string]Layout
+
+// Create a layout from a grid of LayoutWidth by LayoutHeight cells, 1 being a wall
+func NewLayout(grid [][]int) (Layout, error) {
+	if len(grid) != LayoutHeight {
+		return nil, fmt.Errorf("expected %d rows, got %d", LayoutHeight, len(grid))
+	}
+	layout := Layout{}
+	for y, row := range grid {
+		if len(row) != LayoutWidth {
+			return nil, fmt.Errorf("row %d: expected %d columns, got %d", y+1, LayoutWidth, len(row))
+		}
+		for x, cell := range row {
+			if cell == 1 {
+				layout[[2]float32{GridX1 + float32(x), GridY1 + float32(y)}] = true
+			}
+		}
+	}
+	return layout, nil
+}
+
+// Check if the cell at x, y is a wall
+func (l Layout) IsWall(x, y float32) bool {
+	return l[[2]float32{x, y}]
+}
+
+// Return the wall cells, sorted by row then column
+func (l Layout) Cells() [][2]float32 {
+	cells := make([][2]float32, 0, len(l))
+	for cell := range l {
+		cells = append(cells, cell)
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i][1] != cells[j][1] {
+			return cells[i][1] < cells[j][1]
+		}
+		return cells[i][0] < cells[j][0]
+	})
+	return cells
+}
+
+// Raise the walls of the current level, leaving out the cells under the snake, right ahead of its head and under the data points
+func (s *Simulation) buildWalls() {
+	if s.Arena != nil {
+		s.Walls = s.Arena.Walls
+		return
+	}
+	s.Walls = nil
+	layout := s.layouts[s.Level]
+	if len(layout) == 0 {
+		return
+	}
+
+	free := map[[2]float32]bool{}
+	for _, segment := range s.Snake.Body {
+		free[segment] = true
+	}
+	for _, dp := range s.DataPoints {
+		x, y := dp.Position()
+		free[[2]float32{x, y}] = true
+	}
+	moveX, moveY := s.CurrentDir.Vector()
+	x, y := s.Snake.Body[0][0], s.Snake.Body[0][1]
+	for i := 0; i < WallGraceCells; i++ {
+		x, y = wrap(x+float32(moveX), GridX1, GridX2), wrap(y+float32(moveY), GridY1, GridY2)
+		free[[2]float32{x, y}] = true
+	}
+
+	s.Walls = Layout{}
+	for cell := range layout {
+		if !free[cell] {
+			s.Walls[cell] = true
+		}
+	}
+}
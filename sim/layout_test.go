@@ -0,0 +1,24 @@
+package sim_test
+
+import (
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/sim/simtest"
+)
+
+func TestDataPointsNeverOnWalls(t *testing.T) {
+	for _, id := range []string{"google", "amazon", "meta", "microsoft"} {
+		campaign := simtest.LoadCampaign(t, id)
+		for seed := int64(1); seed <= 400; seed++ {
+			s := simtest.NewSimulation(campaign, seed)
+			simtest.Play(s, 3000, func(events sim.Events) {
+				for _, dp := range s.DataPoints {
+					if x, y := dp.Position(); s.Walls.IsWall(x, y) {
+						t.Fatalf("%s seed %d step %d: data point at %v,%v on a wall of %q", id, seed, s.Steps, x, y, s.Level)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Define the temporary effect of a power-up, lasting a number of moves
+type Effect struct {
+	Slug          string
+	Duration      int                       // Moves the effect lasts once collected
+	SpeedFactor   float64                   // Factor of the speed of the snake while active, 0 to leave it
+	PassesThrough bool                      // The snake crosses its own body while active
+	Input         func(Direction) Direction // Mapping of the input while active, nil to leave it
+}
+
+// Effects of the power-ups, in the order they are drawn from
+var PowerUpEffects = []Effect{
+	{Slug: "lobbyist", Duration: 30, PassesThrough: true},
+	{Slug: "data-center", Duration: 40, SpeedFactor: 0.6},
+	{Slug: "privacy-policy-update", Duration: 25, Input: Direction.Opposite},
+}
+
+// Add an effect to the power-ups found in runs
+func RegisterEffect(effect Effect) {
+	PowerUpEffects = append(PowerUpEffects, effect)
+}
+
+// Return the registered effect with the given slug
+func LookupEffect(slug string) (Effect, error) {
+	for _, effect := range PowerUpEffects {
+		if effect.Slug == slug {
+			return effect, nil
+		}
+	}
+	return Effect{}, fmt.Errorf("unknown effect %q", slug)
+}
+
+// Define an effect in progress and the step it wears off at
+type ActiveEffect struct {
+	Slug  string
+	Until int
+}
+
+// Define a power-up in the game, granting its effect when collected
+type PowerUp struct {
+	DataPoint
+	Effect string // Slug of the effect
+}
+
+// Create a power-up of a random effect at a valid random position
+func NewPowerUp(snake Snake, blocked Layout, rng *rand.Rand) PowerUp {
+	effect := PowerUpEffects[rng.Intn(len(PowerUpEffects))]
+	position := GenerateRandomPosition(snake, blocked, rng)
+	return PowerUp{DataPoint: DataPoint{X: position[0], Y: position[1]}, Effect: effect.Slug}
+}
+
+// Start the effect of the power-up, or extend it if it is already active
+func (p PowerUp) OnCollect(s *Simulation, events *Events) {
+	effect, err := LookupEffect(p.Effect)
+	if err != nil {
+		return
+	}
+	events.PowerUp = effect.Slug
+	for i, active := range s.ActiveEffects {
+		if active.Slug == effect.Slug {
+			s.ActiveEffects[i].Until = s.Steps + effect.Duration
+			return
+		}
+	}
+	s.ActiveEffects = append(s.ActiveEffects, ActiveEffect{Slug: effect.Slug, Until: s.Steps + effect.Duration})
+}
+
+// Return the effects in progress, resolved from their slug
+func (s *Simulation) effects() []Effect {
+	var effects []Effect
+	for _, active := range s.ActiveEffects {
+		if effect, err := LookupEffect(active.Slug); err == nil && s.Steps < active.Until {
+			effects = append(effects, effect)
+		}
+	}
+	return effects
+}
+
+// Drop the effects worn off
+func (s *Simulation) expireEffects() {
+	active := s.ActiveEffects[:0]
+	for _, effect := range s.ActiveEffects {
+		if s.Steps < effect.Until {
+			active = append(active, effect)
+		}
+	}
+	s.ActiveEffects = active
+}
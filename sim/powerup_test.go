@@ -0,0 +1,101 @@
+package sim_test
+
+import (
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Return a borderless simulation without data points, having just collected a power-up of effect
+func collectPowerUp(t *testing.T, effect string) *sim.Simulation {
+	t.Helper()
+	s := sim.NewSimulation(nil, nil, nil, sim.Regulation{}, 1, sim.Normal)
+	s.Borderless = true
+	s.DataPoints = []sim.DataPointInterface{sim.PowerUp{DataPoint: sim.DataPoint{X: s.Snake.Body[0][0], Y: s.Snake.Body[0][1]}, Effect: effect}}
+	if events := s.Step(sim.DirNone); events.PowerUp != effect {
+		t.Fatalf("expected the %s power-up to be collected, got %+v", effect, events)
+	}
+	s.DataPoints = nil
+	return s
+}
+
+func TestRegisterEffect(t *testing.T) {
+	effects := sim.PowerUpEffects
+	t.Cleanup(func() { sim.PowerUpEffects = effects })
+
+	if _, err := sim.LookupEffect("antitrust-waiver"); err == nil {
+		t.Fatal("expected an unregistered effect to be unknown")
+	}
+	sim.RegisterEffect(sim.Effect{Slug: "antitrust-waiver", Duration: 5, SpeedFactor: 2})
+	effect, err := sim.LookupEffect("antitrust-waiver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if effect.Duration != 5 || effect.SpeedFactor != 2 {
+		t.Errorf("expected the registered effect, got %+v", effect)
+	}
+
+	s := collectPowerUp(t, "antitrust-waiver")
+	if got, want := s.Speed(), 2*sim.DefaultSpeedCurves[sim.Normal].Speed(s.Pickups, 0); got != want {
+		t.Errorf("expected the registered effect to double the speed to %g, got %g", want, got)
+	}
+}
+
+func TestEffectsWearOffAfterTheirDuration(t *testing.T) {
+	for _, effect := range sim.PowerUpEffects {
+		s := collectPowerUp(t, effect.Slug)
+		for move := 1; move < effect.Duration; move++ {
+			if s.Step(sim.DirNone); len(s.ActiveEffects) != 1 {
+				t.Fatalf("%s: expected the effect to last %d moves, worn off after %d", effect.Slug, effect.Duration, move)
+			}
+		}
+		if s.Step(sim.DirNone); len(s.ActiveEffects) != 0 {
+			t.Errorf("%s: expected the effect to wear off after %d moves", effect.Slug, effect.Duration)
+		}
+	}
+}
+
+func TestCollectingAnActiveEffectExtendsIt(t *testing.T) {
+	s := collectPowerUp(t, "data-center")
+	for i := 0; i < 10; i++ {
+		s.Step(sim.DirNone)
+	}
+	s.DataPoints = []sim.DataPointInterface{sim.PowerUp{DataPoint: sim.DataPoint{X: s.Snake.Body[0][0], Y: s.Snake.Body[0][1]}, Effect: "data-center"}}
+	s.Step(sim.DirNone)
+
+	effect, _ := sim.LookupEffect("data-center")
+	if len(s.ActiveEffects) != 1 || s.ActiveEffects[0].Until != s.Steps+effect.Duration {
+		t.Errorf("expected a single effect lasting until step %d, got %+v", s.Steps+effect.Duration, s.ActiveEffects)
+	}
+}
+
+func TestDataCenterSlowsTheSnakeDown(t *testing.T) {
+	s := collectPowerUp(t, "data-center")
+	if got, want := s.Speed(), 0.6*sim.DefaultSpeedCurves[sim.Normal].Speed(s.Pickups, 0); got != want {
+		t.Errorf("expected %g moves/s, got %g", want, got)
+	}
+}
+
+func TestPrivacyPolicyUpdateReversesTheInput(t *testing.T) {
+	s := collectPowerUp(t, "privacy-policy-update")
+	headY := s.Snake.Body[0][1]
+	s.Step(sim.DirUp)
+	if s.CurrentDir != sim.DirDown || s.Snake.Body[0][1] != headY+1 {
+		t.Errorf("expected up to turn the snake down, got direction %v at row %v", s.CurrentDir, s.Snake.Body[0][1])
+	}
+	if turn := s.Turns[len(s.Turns)-1]; turn.Dir != sim.DirUp {
+		t.Errorf("expected the turn to be logged as given, got %v", turn.Dir)
+	}
+}
+
+func TestLobbyistLetsTheSnakeCrossItself(t *testing.T) {
+	s := collectPowerUp(t, "lobbyist")
+	s.Snake.PendingGrowth = 5
+
+	// Turn back into the body: up, left, down
+	for _, dir := range []sim.Direction{sim.DirUp, sim.DirLeft, sim.DirDown} {
+		if events := s.Step(dir); events.GameOver {
+			t.Fatalf("expected the snake to cross itself going %v", dir)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package sim
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Kind of a quiz question, telling which field of its subject it asks about
+type QuestionKind int
+
+const (
+	QuestionYear    QuestionKind = iota // In which year was the subject acquired
+	QuestionSummary                     // Which company does the summary of the subject describe
+	QuestionPrice                       // Which company was bought for the price of the subject
+)
+
+var questionKindNames = []string{"year", "summary", "price"}
+
+func (k QuestionKind) String() string {
+	if k < 0 || int(k) >= len(questionKindNames) {
+		return "unknown"
+	}
+	return questionKindNames[k]
+}
+
+// Define a multiple-choice question about a special data point
+type Question struct {
+	Kind    QuestionKind
+	Subject SpecialDataPoint
+	Choices []string // Years or names, one of them being the answer
+	Answer  int      // Index of the right choice
+}
+
+// Define the choice made on a quiz question, at the given step of a run
+type QuizAnswer struct {
+	Step   int
+	Choice int
+}
+
+// Define a quiz in progress
+type Quiz struct {
+	Questions []Question
+	Current   int       // Index of the question being asked
+	Answered  bool      // The current question has been answered
+	Choice    int       // Choice made on the current question once answered
+	Correct   int       // Questions answered right so far
+	Outcome   GameState // State the simulation goes to once the quiz is over
+}
+
+// Return the question being asked
+func (q *Quiz) Question() Question {
+	return q.Questions[q.Current]
+}
+
+// Report the outcome of a quiz answer
+type QuizEvents struct {
+	Correct bool  // The right choice was made
+	Points  int64 // Points awarded for the answer
+}
+
+// Start a quiz about the special data points acquired so far, returning false if there is none to ask about
+func (s *Simulation) startQuiz(questions int, outcome GameState) bool {
+	if !s.QuizMode || s.Acquisitions == 0 {
+		return false
+	}
+
+	// Questions only depend on the seed and the step, so re-simulated runs ask the same ones
+	rng := rand.New(rand.NewSource(s.Seed + int64(s.Steps)))
+	acquired := s.initialSpecialDataPoints[:s.Acquisitions]
+	quiz := &Quiz{Outcome: outcome}
+	for _, i := range rng.Perm(len(acquired)) {
+		if len(quiz.Questions) == questions {
+			break
+		}
+		quiz.Questions = append(quiz.Questions, newQuestion(acquired[i], s.initialSpecialDataPoints, rng))
+	}
+
+	s.Quiz = quiz
+	s.State = QuizState
+	return true
+}
+
+// Answer the current question of the quiz with the choice at index choice
+func (s *Simulation) AnswerQuiz(choice int) QuizEvents {
+	var events QuizEvents
+	if s.State != QuizState || s.Quiz.Answered || choice < 0 || choice >= len(s.Quiz.Question().Choices) {
+		return events
+	}
+
+	s.QuizAnswers = append(s.QuizAnswers, QuizAnswer{Step: s.Steps, Choice: choice})
+	s.Quiz.Answered = true
+	s.Quiz.Choice = choice
+	if choice == s.Quiz.Question().Answer {
+		s.Quiz.Correct++
+		s.Breakdown.Quiz += QuizPoints
+		s.Score = s.Breakdown.Total()
+		events.Correct = true
+		events.Points = QuizPoints
+	}
+	return events
+}
+
+// Move on to the next question once the current one is answered, ending the quiz after the last one
+func (s *Simulation) NextQuestion() {
+	if s.State != QuizState || !s.Quiz.Answered {
+		return
+	}
+	s.Quiz.Current++
+	s.Quiz.Answered = false
+	if s.Quiz.Current == len(s.Quiz.Questions) {
+		s.State = s.Quiz.Outcome
+		s.Quiz = nil
+	}
+}
+
+// Generate a question about subject, drawing the wrong choices from specialDataPoints
+func newQuestion(subject SpecialDataPoint, specialDataPoints []SpecialDataPoint, rng *rand.Rand) Question {
+	kinds := []QuestionKind{QuestionYear}
+	if subject.Summary != "" {
+		kinds = append(kinds, QuestionSummary)
+	}
+	if hasUniquePrice(subject, specialDataPoints) {
+		kinds = append(kinds, QuestionPrice)
+	}
+	question := Question{Kind: kinds[rng.Intn(len(kinds))], Subject: subject}
+
+	// Gather the wrong choices, years of the other acquisitions or their names
+	answer := subject.Name
+	var wrong []string
+	if question.Kind == QuestionYear {
+		answer = strconv.Itoa(subject.Year)
+		for _, other := range specialDataPoints {
+			wrong = appendUnique(wrong, strconv.Itoa(other.Year), answer)
+		}
+		// Make up close years when the campaign has too few
+		for offset := 1; len(wrong) < QuizChoices-1; offset++ {
+			wrong = appendUnique(wrong, strconv.Itoa(subject.Year+offset), answer)
+			wrong = appendUnique(wrong, strconv.Itoa(subject.Year-offset), answer)
+		}
+	} else {
+		for _, other := range specialDataPoints {
+			wrong = appendUnique(wrong, other.Name, answer)
+		}
+	}
+	rng.Shuffle(len(wrong), func(i, j int) { wrong[i], wrong[j] = wrong[j], wrong[i] })
+	if len(wrong) > QuizChoices-1 {
+		wrong = wrong[:QuizChoices-1]
+	}
+
+	question.Answer = rng.Intn(len(wrong) + 1)
+	question.Choices = append(question.Choices, wrong[:question.Answer]...)
+	question.Choices = append(question.Choices, answer)
+	question.Choices = append(question.Choices, wrong[question.Answer:]...)
+	return question
+}
+
+// Append choice to choices unless it is already there or is the answer
+func appendUnique(choices []string, choice, answer string) []string {
+	if choice == answer {
+		return choices
+	}
+	for _, existing := range choices {
+		if existing == choice {
+			return choices
+		}
+	}
+	return append(choices, choice)
+}
+
+// Check if the price of subject is known and tells it apart from every other acquisition
+func hasUniquePrice(subject SpecialDataPoint, specialDataPoints []SpecialDataPoint) bool {
+	if !strings.ContainsAny(subject.Price, "0123456789") {
+		return false
+	}
+	for _, other := range specialDataPoints {
+		if other.Slug != subject.Slug && other.Price == subject.Price {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package sim_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/sim/simtest"
+)
+
+// Play a quiz run with the bot until the first quiz is asked
+func quizSimulation(t *testing.T, seed int64) *sim.Simulation {
+	t.Helper()
+	s := simtest.NewSimulation(simtest.LoadCampaign(t, "google"), seed)
+	s.QuizMode = true
+	simtest.Play(s, 20000, nil)
+	if s.State != sim.QuizState {
+		t.Fatalf("expected a quiz once the level changed, got state %v after %d steps", s.State, s.Steps)
+	}
+	return s
+}
+
+func TestQuizAsksAboutAcquisitionsWhenTheLevelChanges(t *testing.T) {
+	s := quizSimulation(t, 1)
+	if len(s.Quiz.Questions) != sim.LevelQuizQuestions {
+		t.Fatalf("expected %d questions, got %d", sim.LevelQuizQuestions, len(s.Quiz.Questions))
+	}
+	acquired := map[string]bool{}
+	for _, acquisition := range s.Acquired {
+		acquired[acquisition.Slug] = true
+	}
+	for i, question := range s.Quiz.Questions {
+		if !acquired[question.Subject.Slug] {
+			t.Errorf("question %d is about %q, which was not acquired", i, question.Subject.Slug)
+		}
+		if len(question.Choices) != sim.QuizChoices {
+			t.Errorf("question %d has %d choices, expected %d", i, len(question.Choices), sim.QuizChoices)
+		}
+		if question.Kind == sim.QuestionYear && question.Choices[question.Answer] != strconv.Itoa(question.Subject.Year) {
+			t.Errorf("question %d answers %q, expected the year %d", i, question.Choices[question.Answer], question.Subject.Year)
+		}
+	}
+}
+
+func TestQuizScoresRightAnswersOnly(t *testing.T) {
+	s := quizSimulation(t, 1)
+	score := s.Score
+
+	question := s.Quiz.Question()
+	if events := s.AnswerQuiz(question.Answer); !events.Correct || events.Points != sim.QuizPoints {
+		t.Fatalf("expected the right answer to earn %d points, got %+v", sim.QuizPoints, events)
+	}
+	if events := s.AnswerQuiz(question.Answer); events.Correct || len(s.QuizAnswers) != 1 {
+		t.Fatalf("expected a question to be answered once, got %+v and %d answers", events, len(s.QuizAnswers))
+	}
+	s.NextQuestion()
+
+	question = s.Quiz.Question()
+	if events := s.AnswerQuiz((question.Answer + 1) % len(question.Choices)); events.Correct || events.Points != 0 {
+		t.Fatalf("expected a wrong answer to earn nothing, got %+v", events)
+	}
+	if s.Score != score+sim.QuizPoints || s.Quiz.Correct != 1 {
+		t.Errorf("expected %d points and 1 right answer, got %d and %d", score+sim.QuizPoints, s.Score, s.Quiz.Correct)
+	}
+}
+
+func TestQuizEndsAfterItsLastQuestion(t *testing.T) {
+	s := quizSimulation(t, 1)
+	outcome := s.Quiz.Outcome
+
+	s.NextQuestion()
+	if s.Quiz.Current != 0 {
+		t.Fatal("expected an unanswered question not to be skipped")
+	}
+	for range s.Quiz.Questions {
+		s.AnswerQuiz(0)
+		s.NextQuestion()
+	}
+	if s.State != outcome || s.Quiz != nil {
+		t.Errorf("expected the quiz to end in state %v, got %v with quiz %v", outcome, s.State, s.Quiz)
+	}
+}
+
+func TestQuizQuestionsDependOnTheSeedOnly(t *testing.T) {
+	if a, b := quizSimulation(t, 3), quizSimulation(t, 3); !reflect.DeepEqual(a.Quiz.Questions, b.Quiz.Questions) {
+		t.Errorf("expected the same questions for the same seed, got %+v and %+v", a.Quiz.Questions, b.Quiz.Questions)
+	}
+}
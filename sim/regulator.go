@@ -0,0 +1,169 @@
+package sim
+
+// Define when the antitrust regulator shows up and what it does on contact with the snake
+type Regulation struct {
+	Level  string // Level the regulator shows up at, empty for none
+	Period int    // Moves of the snake per move of the regulator, 0 for RegulatorPeriod
+	Fine   int64  // Points fined on contact, the run ending on contact when both Fine and Cut are 0
+	Cut    int    // Cells cut from the tail of the snake on contact
+}
+
+// Define the regulator hunting the head of the snake
+type Regulator struct {
+	X, Y  float32
+	Moves int // Moves of the snake since the regulator showed up
+}
+
+// Check if the regulator stands on the head of the snake
+func (r Regulator) Catches(snake Snake) bool {
+	return r.X == snake.Body[0][0] && r.Y == snake.Body[0][1]
+}
+
+func (r Regulation) period() int {
+	if r.Period > 0 {
+		return r.Period
+	}
+	return RegulatorPeriod
+}
+
+// Return the index of level in the order the special data points go through the levels, -1 if none is at level
+func (s *Simulation) levelIndex(level string) int {
+	index := -1
+	previous := ""
+	for _, special := range s.initialSpecialDataPoints {
+		if index < 0 || special.Level != previous {
+			index++
+			previous = special.Level
+		}
+		if special.Level == level {
+			return index
+		}
+	}
+	return -1
+}
+
+// Bring the regulator in from its level on, move it toward the head of the snake and enforce on contact, only while the snake is in play
+func (s *Simulation) regulate(events *Events) {
+	if s.State != PlayState {
+		return
+	}
+	if s.Regulator == nil {
+		if at := s.levelIndex(s.regulation.Level); at >= 0 && s.levelIndex(s.Level) >= at {
+			s.Regulator = s.newRegulator()
+		}
+		return
+	}
+
+	if !s.Regulator.Catches(s.Snake) {
+		s.Regulator.Moves++
+		if s.Regulator.Moves%s.regulation.period() == 0 {
+			s.Regulator.X, s.Regulator.Y = s.chase(s.Regulator.X, s.Regulator.Y)
+		}
+	}
+	if s.Regulator.Catches(s.Snake) {
+		s.enforce(events)
+	}
+}
+
+// Place a regulator away from the head of the snake and the data points
+func (s *Simulation) newRegulator() *Regulator {
+	headX, headY := s.Snake.Body[0][0], s.Snake.Body[0][1]
+	var position [2]float32
+	for try := 0; try < RegulatorSpawnTries; try++ {
+		position = GenerateRandomPosition(s.Snake, s.occupied(), s.rng)
+		if abs(position[0]-headX)+abs(position[1]-headY) >= RegulatorSpawnDistance {
+			break
+		}
+	}
+	return &Regulator{X: position[0], Y: position[1]}
+}
+
+// End the run, or fine the score and cut the snake before sending the regulator elsewhere
+func (s *Simulation) enforce(events *Events) {
+	events.Regulated = true
+	if s.regulation.Fine == 0 && s.regulation.Cut == 0 {
+		s.State = GameOverState
+		s.startQuiz(FinalQuizQuestions, GameOverState)
+		events.GameOver = true
+		return
+	}
+
+	fine := s.regulation.Fine
+	if fine > s.Score {
+		fine = s.Score
+	}
+	s.Breakdown.Fines += fine
+	s.Score = s.Breakdown.Total()
+	events.Fine = fine
+
+	length := len(s.Snake.Body) - s.regulation.Cut
+	if length < 1 {
+		length = 1
+	}
+	s.Snake.Body = s.Snake.Body[:length]
+
+	s.Regulator = s.newRegulator()
+}
+
+// Return the cell next to x, y on a shortest path to the head of the snake, around the walls and the body
+func (s *Simulation) chase(x, y float32) (float32, float32) {
+	distances := s.distancesToHead()
+
+	// Step to the closest neighbour, staying put if the head is out of reach
+	best, bestDistance := [2]float32{x, y}, -1
+	if distance, reachable := distances[best]; reachable {
+		bestDistance = distance
+	}
+	for _, next := range s.neighbours([2]float32{x, y}) {
+		if distance, reachable := distances[next]; reachable && (bestDistance < 0 || distance < bestDistance) {
+			best, bestDistance = next, distance
+		}
+	}
+	return best[0], best[1]
+}
+
+// Measure the distance of every cell reachable from the head of the snake, breadth first around the walls and the body
+func (s *Simulation) distancesToHead() map[[2]float32]int {
+	head := s.Snake.Body[0]
+	blocked := Layout{}
+	for _, segment := range s.Snake.Body[1:] {
+		blocked[segment] = true
+	}
+	distances := map[[2]float32]int{head: 0}
+	queue := [][2]float32{head}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		for _, next := range s.neighbours(cell) {
+			if _, seen := distances[next]; seen || blocked[next] || s.Walls.IsWall(next[0], next[1]) {
+				continue
+			}
+			distances[next] = distances[cell] + 1
+			queue = append(queue, next)
+		}
+	}
+	return distances
+}
+
+// Return the cells next to cell in the play area, in Direction order, wrapping around in borderless mode
+func (s *Simulation) neighbours(cell [2]float32) [][2]float32 {
+	var cells [][2]float32
+	for dir := DirUp; dir < DirNone; dir++ {
+		moveX, moveY := dir.Vector()
+		x, y := cell[0]+float32(moveX), cell[1]+float32(moveY)
+		if s.Borderless {
+			x, y = wrap(x, GridX1, GridX2), wrap(y, GridY1, GridY2)
+		} else if x < GridX1 || x >= GridX2 || y < GridY1 || y >= GridY2 {
+			continue
+		}
+		cells = append(cells, [2]float32{x, y})
+	}
+	return cells
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,91 @@
+package sim_test
+
+import (
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+func TestRegulatorOnlyEnforcesInPlay(t *testing.T) {
+	specials := []sim.SpecialDataPoint{
+		{Name: "First", Slug: "first", Level: "Start"},
+		{Name: "Second", Slug: "second", Level: "Start"},
+	}
+	s := sim.NewSimulation(specials, nil, nil, sim.Regulation{Level: "Start"}, 1, sim.Normal)
+	headX, headY := s.Snake.Body[0][0], s.Snake.Body[0][1]
+	moveX, moveY := s.CurrentDir.Vector()
+
+	// Collect the special data point under the head as the regulator waits on the next cell
+	special := specials[0]
+	special.X, special.Y = headX, headY
+	s.SpecialDataPoints = specials[1:]
+	s.DataPoints = []sim.DataPointInterface{special}
+	s.Regulator = &sim.Regulator{X: headX + float32(moveX), Y: headY + float32(moveY)}
+
+	events := s.Step(sim.DirNone)
+	if !events.Special || s.State != sim.SpecialState {
+		t.Fatalf("expected the special data point to be collected, got state %d and events %+v", s.State, events)
+	}
+	if events.Regulated || events.GameOver {
+		t.Fatalf("regulator enforced outside of PlayState: %+v", events)
+	}
+}
+
+func TestRegulatorShowsUpFromItsLevelOn(t *testing.T) {
+	specials := []sim.SpecialDataPoint{
+		{Name: "First", Slug: "first", Level: "Search"},
+		{Name: "Second", Slug: "second", Level: "Privacy"},
+		{Name: "Third", Slug: "third", Level: "Household"},
+	}
+	tests := []struct {
+		regulation, level string
+		want              bool
+	}{
+		{"Privacy", "Search", false},
+		{"Privacy", "Privacy", true},
+		{"Privacy", "Household", true}, // Reached past the regulation level
+		{"Unknown", "Household", false},
+		{"", "Household", false},
+	}
+	for _, test := range tests {
+		s := sim.NewSimulation(specials, nil, nil, sim.Regulation{Level: test.regulation}, 1, sim.Normal)
+		s.DataPoints = nil
+		s.Level = test.level
+		s.Step(sim.DirNone)
+		if got := s.Regulator != nil; got != test.want {
+			t.Errorf("regulation at %q, level %q: expected a regulator %v, got %v", test.regulation, test.level, test.want, got)
+		}
+	}
+}
+
+func TestDataPointsNeverShowUpOnTheRegulator(t *testing.T) {
+	s := sim.NewSimulation(nil, nil, nil, sim.Regulation{}, 1, sim.Normal)
+	headX, headY := s.Snake.Body[0][0], s.Snake.Body[0][1]
+	moveX, moveY := s.CurrentDir.Vector()
+	next := [2]float32{headX + float32(moveX), headY + float32(moveY)}
+	regulator := [2]float32{headX + 3*float32(moveX), headY + 3*float32(moveY)}
+
+	// Wall every cell but the snake, the cell ahead of it and the regulator
+	s.Walls = sim.Layout{}
+	for x := sim.GridX1; x < sim.GridX2; x++ {
+		for y := sim.GridY1; y < sim.GridY2; y++ {
+			s.Walls[[2]float32{x, y}] = true
+		}
+	}
+	for _, segment := range s.Snake.Body {
+		delete(s.Walls, segment)
+	}
+	delete(s.Walls, next)
+	delete(s.Walls, regulator)
+	s.Regulator = &sim.Regulator{X: regulator[0], Y: regulator[1]}
+	s.DataPoints = []sim.DataPointInterface{sim.DataPoint{X: headX, Y: headY}}
+
+	if events := s.Step(sim.DirNone); !events.Collected || events.GameOver {
+		t.Fatalf("expected the data point under the head to be collected, got %+v", events)
+	}
+	for _, dp := range s.DataPoints {
+		if x, y := dp.Position(); x == regulator[0] && y == regulator[1] {
+			t.Fatalf("data point showed up on the regulator at %v", regulator)
+		}
+	}
+}
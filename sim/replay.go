@@ -0,0 +1,183 @@
+package sim
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Version of the replay file format
+const ReplayVersion = 1
+
+const replayHeader = "snakeopoly-replay"
+
+// Kind of a recorded input
+type InputKind byte
+
+const (
+	InputDirection InputKind = 'd' // Value is the Direction the snake was steered to
+	InputKey       InputKind = 'k' // Value is the rune of a macro key
+)
+
+// Define a single input applied right before the given tick
+type ReplayInput struct {
+	Tick  int64
+	Kind  InputKind
+	Value int32
+}
+
+// Define a recording of a session, replayable tick for tick
+type Replay struct {
+	Campaign   string // Campaign selected when the session started
+	Difficulty Difficulty
+	Borderless bool    // Borderless mode was on when the session started
+	Quiz       bool    // Quiz mode was on
+	Seeds      []int64 // Seed of each run, in the order they were played
+	Inputs     []ReplayInput
+}
+
+// Append an input applied right before tick
+func (r *Replay) Record(tick int64, kind InputKind, value int32) {
+	r.Inputs = append(r.Inputs, ReplayInput{Tick: tick, Kind: kind, Value: value})
+}
+
+// Encode the replay as a header, the campaign, the difficulty, the borderless and quiz modes, the seeds and one line per input
+func (r *Replay) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s %d\n", replayHeader, ReplayVersion)
+	fmt.Fprintf(bw, "campaign %s\n", r.Campaign)
+	fmt.Fprintf(bw, "difficulty %s\n", r.Difficulty)
+	fmt.Fprintf(bw, "borderless %t\n", r.Borderless)
+	fmt.Fprintf(bw, "quiz %t\n", r.Quiz)
+
+	seeds := make([]string, len(r.Seeds))
+	for i, seed := range r.Seeds {
+		seeds[i] = strconv.FormatInt(seed, 10)
+	}
+	fmt.Fprintf(bw, "seeds %s\n", strings.Join(seeds, " "))
+
+	for _, input := range r.Inputs {
+		fmt.Fprintf(bw, "%d %c %d\n", input.Tick, input.Kind, input.Value)
+	}
+	return bw.Flush()
+}
+
+// Decode a replay written by Write
+func ReadReplay(r io.Reader) (*Replay, error) {
+	scanner := bufio.NewScanner(r)
+	replay := &Replay{}
+
+	// Check the header and its version
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("replay: missing header")
+	}
+	var header string
+	var version int
+	if _, err := fmt.Sscanf(scanner.Text(), "%s %d", &header, &version); err != nil || header != replayHeader {
+		return nil, fmt.Errorf("replay: invalid header %q", scanner.Text())
+	}
+	if version != ReplayVersion {
+		return nil, fmt.Errorf("replay: unsupported version %d", version)
+	}
+
+	// Read the campaign
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("replay: missing campaign")
+	}
+	if _, err := fmt.Sscanf(scanner.Text(), "campaign %s", &replay.Campaign); err != nil {
+		return nil, fmt.Errorf("replay: invalid campaign line %q", scanner.Text())
+	}
+
+	// Read the difficulty
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("replay: missing difficulty")
+	}
+	var difficulty string
+	if _, err := fmt.Sscanf(scanner.Text(), "difficulty %s", &difficulty); err != nil {
+		return nil, fmt.Errorf("replay: invalid difficulty line %q", scanner.Text())
+	}
+	var err error
+	if replay.Difficulty, err = ParseDifficulty(difficulty); err != nil {
+		return nil, fmt.Errorf("replay: %v", err)
+	}
+
+	// Read the borderless mode
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("replay: missing borderless mode")
+	}
+	if _, err := fmt.Sscanf(scanner.Text(), "borderless %t", &replay.Borderless); err != nil {
+		return nil, fmt.Errorf("replay: invalid borderless line %q", scanner.Text())
+	}
+
+	// Read the quiz mode
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("replay: missing quiz mode")
+	}
+	if _, err := fmt.Sscanf(scanner.Text(), "quiz %t", &replay.Quiz); err != nil {
+		return nil, fmt.Errorf("replay: invalid quiz line %q", scanner.Text())
+	}
+
+	// Read the seeds of every run
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("replay: missing seeds")
+	}
+	fields := strings.Fields(scanner.Text())
+	if len(fields) == 0 || fields[0] != "seeds" {
+		return nil, fmt.Errorf("replay: invalid seeds line %q", scanner.Text())
+	}
+	for _, field := range fields[1:] {
+		seed, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("replay: invalid seed %q", field)
+		}
+		replay.Seeds = append(replay.Seeds, seed)
+	}
+
+	// Read every input
+	line := 6
+	for scanner.Scan() {
+		line++
+		var input ReplayInput
+		var kind rune
+		if _, err := fmt.Sscanf(scanner.Text(), "%d %c %d", &input.Tick, &kind, &input.Value); err != nil {
+			return nil, fmt.Errorf("replay: line %d: %v", line, err)
+		}
+		input.Kind = InputKind(kind)
+		if input.Kind != InputDirection && input.Kind != InputKey {
+			return nil, fmt.Errorf("replay: line %d: unknown input kind %q", line, kind)
+		}
+		replay.Inputs = append(replay.Inputs, input)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return replay, nil
+}
+
+// Write the replay to the file at path
+func (r *Replay) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Read the replay from the file at path
+func LoadReplay(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadReplay(file)
+}
@@ -0,0 +1,65 @@
+package sim_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/sim/simtest"
+)
+
+func TestReplayRoundTrip(t *testing.T) {
+	campaign := simtest.LoadCampaign(t, "google")
+	const seed = 11
+
+	// Record the directions of a bot run, one tick per step, as the game records the keyboard
+	played := simtest.NewSimulation(campaign, seed)
+	recording := &sim.Replay{Campaign: campaign.ID(), Difficulty: sim.Nokia, Borderless: true, Quiz: true, Seeds: []int64{seed}}
+	nextDir := played.CurrentDir
+	for played.Steps < 2000 && (played.State == sim.PlayState || played.State == sim.SpecialState) {
+		played.Resume()
+		if dir := simtest.BotInput(played); dir != sim.DirNone && dir != nextDir {
+			recording.Record(int64(played.Steps), sim.InputDirection, int32(dir))
+			nextDir = dir
+		}
+		played.Step(nextDir)
+	}
+	recording.Record(int64(played.Steps), sim.InputKey, 113)
+
+	var buf bytes.Buffer
+	if err := recording.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	replay, err := sim.ReadReplay(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(replay, recording) {
+		t.Fatalf("replay changed through the file:\n%+v\n%+v", replay, recording)
+	}
+
+	// Play the inputs back into a new simulation
+	replayed := simtest.NewSimulation(campaign, replay.Seeds[0])
+	nextDir = replayed.CurrentDir
+	input := 0
+	for replayed.Steps < played.Steps {
+		replayed.Resume()
+		for input < len(replay.Inputs) && replay.Inputs[input].Tick <= int64(replayed.Steps) {
+			if replay.Inputs[input].Kind == sim.InputDirection {
+				nextDir = sim.Direction(replay.Inputs[input].Value)
+			}
+			input++
+		}
+		replayed.Step(nextDir)
+	}
+	if !reflect.DeepEqual(replayed.Snapshot(), played.Snapshot()) {
+		t.Fatalf("replayed run differs from the played one after %d steps", played.Steps)
+	}
+}
+
+func TestReadReplayRejectsOtherVersions(t *testing.T) {
+	if _, err := sim.ReadReplay(bytes.NewBufferString("snakeopoly-replay 0\n")); err == nil {
+		t.Fatal("expected another replay version to be rejected")
+	}
+}
@@ -0,0 +1,64 @@
+package sim
+
+// Define a change of direction, taken at the given step of a run
+type Turn struct {
+	Step int
+	Dir  Direction
+}
+
+// Define the minimal record needed to re-simulate a run
+type RunLog struct {
+	Campaign   string
+	Seed       int64
+	Difficulty Difficulty
+	Steps      int
+	Turns      []Turn
+	Borderless bool         `json:",omitempty"` // Played with the snake wrapping around the edges
+	Quiz       bool         `json:",omitempty"` // Played in quiz mode
+	Answers    []QuizAnswer `json:",omitempty"` // Answers of the quiz questions, in order
+}
+
+// Capture the log of the current run
+func (s *Simulation) Log() RunLog {
+	return RunLog{
+		Campaign:   s.CampaignID,
+		Seed:       s.Seed,
+		Difficulty: s.Difficulty,
+		Steps:      s.Steps,
+		Turns:      append([]Turn(nil), s.Turns...),
+		Borderless: s.Borderless,
+		Quiz:       s.QuizMode,
+		Answers:    append([]QuizAnswer(nil), s.QuizAnswers...),
+	}
+}
+
+// Re-simulate a run from its log, acknowledging every special data point and answering every quiz on the way
+func Resimulate(specialDataPoints []SpecialDataPoint, layouts Layouts, behaviors Behaviors, regulation Regulation, log RunLog) *Simulation {
+	s := NewSimulation(specialDataPoints, layouts, behaviors, regulation, log.Seed, log.Difficulty)
+	s.CampaignID = log.Campaign
+	s.Borderless = log.Borderless
+	s.QuizMode = log.Quiz
+	turn := 0
+	answer := 0
+	for step := 0; step < log.Steps && s.State == PlayState; step++ {
+		input := DirNone
+		if turn < len(log.Turns) && log.Turns[turn].Step == step {
+			input = log.Turns[turn].Dir
+			turn++
+		}
+		s.Step(input)
+		s.Resume()
+		answer = s.answerLogged(log.Answers, answer)
+	}
+	return s
+}
+
+// Answer the quiz in progress with the logged answers taken at the current step, returning the index of the next one
+func (s *Simulation) answerLogged(answers []QuizAnswer, next int) int {
+	for s.State == QuizState && next < len(answers) && answers[next].Step == s.Steps {
+		s.AnswerQuiz(answers[next].Choice)
+		s.NextQuestion()
+		next++
+	}
+	return next
+}
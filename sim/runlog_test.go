@@ -0,0 +1,24 @@
+package sim_test
+
+import (
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/sim/simtest"
+)
+
+func TestResimulateMatchesLiveScore(t *testing.T) {
+	for _, id := range []string{"google", "meta"} {
+		campaign := simtest.LoadCampaign(t, id)
+		for seed := int64(1); seed <= 20; seed++ {
+			s := simtest.NewSimulation(campaign, seed)
+			simtest.Play(s, 5000, nil)
+
+			replayed := sim.Resimulate(campaign.SpecialDataPoints, campaign.Layouts, campaign.Behaviors, campaign.Regulator, s.Log())
+			if replayed.Score != s.Score || replayed.Level != s.Level || replayed.Acquisitions != s.Acquisitions || replayed.State != s.State {
+				t.Fatalf("%s seed %d: re-simulated %d points at %q in state %d, played %d points at %q in state %d",
+					id, seed, replayed.Score, replayed.Level, replayed.State, s.Score, s.Level, s.State)
+			}
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package sim
+
+import "fmt"
+
+// Version of the save format, bumped on every incompatible change
+const SaveVersion = 1
+
+// Define a data point as saved, special ones referring to their entry by slug and power-ups to their effect
+type SavedDataPoint struct {
+	X, Y     float32
+	Special  bool
+	Slug     string `json:",omitempty"`
+	Effect   string `json:",omitempty"`
+	Fleeting bool   `json:",omitempty"`
+	Age      int    `json:",omitempty"`
+}
+
+// Define everything needed to resume a game in progress
+type SaveData struct {
+	Version              int
+	Campaign             string
+	Seed                 int64
+	Difficulty           Difficulty
+	Steps                int
+	Turns                []Turn
+	Borderless           bool
+	QuizMode             bool
+	QuizAnswers          []QuizAnswer
+	QuizDue              bool
+	SnakeBody            [][2]float32
+	PendingGrowth        int
+	CurrentDir           Direction
+	DataPoints           []SavedDataPoint
+	CurrentSpecialSlug   string   `json:",omitempty"`
+	SpecialDataPoints    []string // Slugs of the special data points still to come, in order
+	LastSpecialDataPoint bool
+	Score                int64
+	Breakdown            ScoreBreakdown
+	Pickups              int
+	Acquisitions         int
+	Acquired             []Acquisition
+	Streak               int
+	MovesSincePickup     int
+	Level                string
+	LevelChanges         int
+	PlayTime             float64
+	Walls                [][2]float32   `json:",omitempty"`
+	Regulator            *Regulator     `json:",omitempty"`
+	ActiveEffects        []ActiveEffect `json:",omitempty"`
+	State                GameState
+}
+
+// Capture the current game into SaveData
+func (s *Simulation) Snapshot() SaveData {
+	data := SaveData{
+		Version:              SaveVersion,
+		Campaign:             s.CampaignID,
+		Seed:                 s.Seed,
+		Difficulty:           s.Difficulty,
+		Steps:                s.Steps,
+		Turns:                append([]Turn(nil), s.Turns...),
+		Borderless:           s.Borderless,
+		QuizMode:             s.QuizMode,
+		QuizAnswers:          append([]QuizAnswer(nil), s.QuizAnswers...),
+		QuizDue:              s.quizDue,
+		SnakeBody:            append([][2]float32(nil), s.Snake.Body...),
+		PendingGrowth:        s.Snake.PendingGrowth,
+		CurrentDir:           s.CurrentDir,
+		CurrentSpecialSlug:   s.CurrentSpecialDataPoint.Slug,
+		LastSpecialDataPoint: s.LastSpecialDataPoint,
+		Score:                s.Score,
+		Breakdown:            s.Breakdown,
+		Pickups:              s.Pickups,
+		Acquisitions:         s.Acquisitions,
+		Acquired:             append([]Acquisition(nil), s.Acquired...),
+		Streak:               s.Streak,
+		MovesSincePickup:     s.movesSincePickup,
+		Level:                s.Level,
+		LevelChanges:         s.LevelChanges,
+		PlayTime:             s.PlayTime,
+		Walls:                s.Walls.Cells(),
+		Regulator:            s.Regulator,
+		ActiveEffects:        append([]ActiveEffect(nil), s.ActiveEffects...),
+		State:                s.State,
+	}
+
+	for _, dp := range s.DataPoints {
+		data.DataPoints = append(data.DataPoints, saveDataPoint(dp))
+	}
+
+	for _, special := range s.SpecialDataPoints {
+		data.SpecialDataPoints = append(data.SpecialDataPoints, special.Slug)
+	}
+
+	return data
+}
+
+// Resume the game captured in data, resolving special data points from their slug
+func (s *Simulation) Restore(data SaveData) error {
+	if data.Version != SaveVersion {
+		return fmt.Errorf("save: unsupported version %d", data.Version)
+	}
+	if len(data.SnakeBody) == 0 {
+		return fmt.Errorf("save: empty snake")
+	}
+
+	// Resolve every special data point before touching the simulation
+	dataPoints := make([]DataPointInterface, 0, len(data.DataPoints))
+	for _, saved := range data.DataPoints {
+		dp, err := s.restoreDataPoint(saved)
+		if err != nil {
+			return err
+		}
+		dataPoints = append(dataPoints, dp)
+	}
+
+	var currentSpecialDataPoint SpecialDataPoint
+	if data.CurrentSpecialSlug != "" {
+		special, err := s.specialDataPoint(data.CurrentSpecialSlug)
+		if err != nil {
+			return err
+		}
+		currentSpecialDataPoint = special
+	}
+
+	for _, acquisition := range data.Acquired {
+		if _, err := s.specialDataPoint(acquisition.Slug); err != nil {
+			return err
+		}
+	}
+
+	specialDataPoints := make([]SpecialDataPoint, 0, len(data.SpecialDataPoints))
+	for _, slug := range data.SpecialDataPoints {
+		special, err := s.specialDataPoint(slug)
+		if err != nil {
+			return err
+		}
+		specialDataPoints = append(specialDataPoints, special)
+	}
+
+	// Re-simulate the run to recover the exact random state and the quiz in progress, keeping the run verifiable
+	replayed := Resimulate(s.initialSpecialDataPoints, s.layouts, s.behaviors, s.regulation, RunLog{Campaign: data.Campaign, Seed: data.Seed, Difficulty: data.Difficulty, Steps: data.Steps, Turns: data.Turns, Borderless: data.Borderless, Quiz: data.QuizMode, Answers: data.QuizAnswers})
+	if replayed.Score != data.Score || replayed.Pickups != data.Pickups || len(replayed.Snake.Body) != len(data.SnakeBody) {
+		return fmt.Errorf("save: the inputs of the run do not replay to the saved game")
+	}
+	if data.State == QuizState && replayed.State != QuizState {
+		return fmt.Errorf("save: quiz could not be recovered")
+	}
+	s.rng = replayed.rng
+	s.Quiz = nil
+	if data.State == QuizState {
+		s.Quiz = replayed.Quiz
+	}
+
+	s.Seed = data.Seed
+	s.CampaignID = data.Campaign
+	s.Difficulty = data.Difficulty
+	s.Rules = data.Difficulty.Rules()
+	s.Steps = data.Steps
+	s.Turns = append([]Turn(nil), data.Turns...)
+	s.Borderless = data.Borderless
+	s.QuizMode = data.QuizMode
+	s.QuizAnswers = append([]QuizAnswer(nil), data.QuizAnswers...)
+	s.quizDue = data.QuizDue
+	s.Snake = Snake{Body: append([][2]float32(nil), data.SnakeBody...), PendingGrowth: data.PendingGrowth}
+	s.CurrentDir = data.CurrentDir
+	s.DataPoints = dataPoints
+	s.CurrentSpecialDataPoint = currentSpecialDataPoint
+	s.SpecialDataPoints = specialDataPoints
+	s.LastSpecialDataPoint = data.LastSpecialDataPoint
+	s.Score = data.Score
+	s.Breakdown = data.Breakdown
+	s.Pickups = data.Pickups
+	s.Acquisitions = data.Acquisitions
+	s.Acquired = append([]Acquisition(nil), data.Acquired...)
+	s.buildTimeline()
+	s.Streak = data.Streak
+	s.movesSincePickup = data.MovesSincePickup
+	s.Level = data.Level
+	s.LevelChanges = data.LevelChanges
+	s.PlayTime = data.PlayTime
+	s.Walls = nil
+	for _, cell := range data.Walls {
+		if s.Walls == nil {
+			s.Walls = Layout{}
+		}
+		s.Walls[cell] = true
+	}
+	s.Regulator = nil
+	if data.Regulator != nil {
+		regulator := *data.Regulator
+		s.Regulator = &regulator
+	}
+	s.ActiveEffects = append([]ActiveEffect(nil), data.ActiveEffects...)
+	s.State = data.State
+	return nil
+}
+
+// Capture a data point of the board
+func saveDataPoint(dp DataPointInterface) SavedDataPoint {
+	x, y := dp.Position()
+	saved := SavedDataPoint{X: x, Y: y}
+	if special, isSpecial := dp.(SpecialDataPoint); isSpecial {
+		saved.Special = true
+		saved.Slug = special.Slug
+	} else if powerUp, isPowerUp := dp.(PowerUp); isPowerUp {
+		saved.Effect = powerUp.Effect
+	} else if fleeting, isFleeting := dp.(FleetingDataPoint); isFleeting {
+		saved.Fleeting = true
+		saved.Age = fleeting.Age
+	}
+	return saved
+}
+
+// Resolve a saved data point, special ones from their slug and power-ups from their effect
+func (s *Simulation) restoreDataPoint(saved SavedDataPoint) (DataPointInterface, error) {
+	dp := DataPoint{X: saved.X, Y: saved.Y}
+	if saved.Special {
+		special, err := s.specialDataPoint(saved.Slug)
+		if err != nil {
+			return nil, err
+		}
+		special.DataPoint = dp
+		return special, nil
+	} else if saved.Effect != "" {
+		if _, err := LookupEffect(saved.Effect); err != nil {
+			return nil, fmt.Errorf("save: %w", err)
+		}
+		return PowerUp{DataPoint: dp, Effect: saved.Effect}, nil
+	} else if saved.Fleeting {
+		return FleetingDataPoint{DataPoint: dp, Age: saved.Age}, nil
+	}
+	return dp, nil
+}
+
+// Find the special data point with the given slug
+func (s *Simulation) specialDataPoint(slug string) (SpecialDataPoint, error) {
+	for _, special := range s.initialSpecialDataPoints {
+		if special.Slug == slug {
+			return special, nil
+		}
+	}
+	return SpecialDataPoint{}, fmt.Errorf("save: unknown special data point %q", slug)
+}
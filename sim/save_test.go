@@ -0,0 +1,84 @@
+package sim_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/sim/simtest"
+)
+
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	campaign := simtest.LoadCampaign(t, "google")
+	for seed := int64(1); seed <= 20; seed++ {
+		s := simtest.NewSimulation(campaign, seed)
+		simtest.Play(s, 150, nil)
+		if s.State != sim.PlayState {
+			continue
+		}
+
+		// Go through JSON as the save file does
+		fileData, err := json.Marshal(s.Snapshot())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var data sim.SaveData
+		if err := json.Unmarshal(fileData, &data); err != nil {
+			t.Fatal(err)
+		}
+		restored := simtest.NewSimulation(campaign, 0)
+		if err := restored.Restore(data); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if !reflect.DeepEqual(restored.Snapshot(), s.Snapshot()) {
+			t.Fatalf("seed %d: restored snapshot differs", seed)
+		}
+
+		// The restored run goes on exactly as the original one
+		for i := 0; i < 300 && s.State == sim.PlayState; i++ {
+			input := simtest.BotInput(s)
+			s.Step(input)
+			restored.Step(input)
+			s.Resume()
+			restored.Resume()
+		}
+		if !reflect.DeepEqual(restored.Snapshot(), s.Snapshot()) {
+			t.Fatalf("seed %d: restored run diverged by step %d", seed, s.Steps)
+		}
+	}
+}
+
+func TestRestoreRejectsOtherVersions(t *testing.T) {
+	s := sim.NewSimulation(nil, nil, nil, sim.Regulation{}, 1, sim.Normal)
+	data := s.Snapshot()
+	data.Version--
+	if err := s.Restore(data); err == nil {
+		t.Fatal("expected an older save version to be rejected")
+	}
+}
+
+func TestRestoreRejectsSavesTheInputsDoNotReplayTo(t *testing.T) {
+	campaign := simtest.LoadCampaign(t, "google")
+	s := simtest.NewSimulation(campaign, 3)
+	simtest.Play(s, 150, nil)
+
+	data := s.Snapshot()
+	data.Score += 1000
+	restored := simtest.NewSimulation(campaign, 0)
+	if err := restored.Restore(data); err == nil {
+		t.Fatal("expected a save with a tampered score to be rejected")
+	}
+	if restored.Score != 0 || restored.Steps != 0 {
+		t.Fatalf("rejected save changed the simulation: score %d after %d steps", restored.Score, restored.Steps)
+	}
+}
+
+func TestRestoreRejectsUnknownAcquisitions(t *testing.T) {
+	s := simtest.NewSimulation(simtest.LoadCampaign(t, "google"), 1)
+	data := s.Snapshot()
+	data.Acquired = append(data.Acquired, sim.Acquisition{Slug: "unknown"})
+	if err := s.Restore(data); err == nil {
+		t.Fatal("expected an acquisition missing from the campaign to be rejected")
+	}
+}
@@ -0,0 +1,61 @@
+package sim
+
+// Define how the score of a run was earned
+type ScoreBreakdown struct {
+	Base       int64 // Values of the data points collected
+	Streak     int64 // Extra points from streak multipliers
+	SpeedBonus int64 // Extra points for reaching data points quickly
+	Quiz       int64 // Points of the quiz questions answered right
+	Fines      int64 // Points fined by the regulator
+	BestStreak int   // Longest run of quick pickups
+}
+
+// Return the total score
+func (b ScoreBreakdown) Total() int64 {
+	return b.Base + b.Streak + b.SpeedBonus + b.Quiz - b.Fines
+}
+
+// Return the base value of a data point
+func DataPointValue(dp DataPointInterface) int64 {
+	if special, isSpecial := dp.(SpecialDataPoint); isSpecial {
+		if special.Value > 0 {
+			return special.Value
+		}
+		return SpecialDataPointValue
+	}
+	return RegularDataPointValue
+}
+
+// Award the points of a collected data point and return them
+func (s *Simulation) scorePickup(dp DataPointInterface) int64 {
+	base := DataPointValue(dp)
+
+	// Quick pickups extend the streak, slow ones start a new one
+	if s.Pickups > 0 && s.movesSincePickup <= StreakWindow {
+		s.Streak++
+	} else {
+		s.Streak = 1
+	}
+	if s.Streak > s.Breakdown.BestStreak {
+		s.Breakdown.BestStreak = s.Streak
+	}
+	multiplier := int64(s.Streak)
+	if multiplier > MaxStreakMultiplier {
+		multiplier = MaxStreakMultiplier
+	}
+
+	// Every move under the speed bonus window is worth a point
+	var speedBonus int64
+	if s.movesSincePickup < SpeedBonusWindow {
+		speedBonus = int64(SpeedBonusWindow - s.movesSincePickup)
+	}
+
+	s.Breakdown.Base += base
+	s.Breakdown.Streak += base * (multiplier - 1)
+	s.Breakdown.SpeedBonus += speedBonus
+	s.Score = s.Breakdown.Total()
+	s.Pickups++
+	s.movesSincePickup = 0
+
+	return base*multiplier + speedBonus
+}
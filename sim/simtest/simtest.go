@@ -0,0 +1,103 @@
+// Package simtest provides campaigns and a bot to drive simulations in tests
+package simtest
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/content"
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+// Load the embedded campaign with the given ID from the assets of the repository
+func LoadCampaign(t testing.TB, id string) *content.Campaign {
+	t.Helper()
+	_, file, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(file), "..", "..", "assets", "campaigns", id)
+	campaign, err := content.Load(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("load campaign %s: %v", id, err)
+	}
+	return campaign
+}
+
+// Start a new simulation of campaign
+func NewSimulation(campaign *content.Campaign, seed int64) *sim.Simulation {
+	s := sim.NewSimulation(campaign.SpecialDataPoints, campaign.Layouts, campaign.Behaviors, campaign.Regulator, seed, sim.Normal)
+	s.CampaignID = campaign.ID()
+	s.Speeds = campaign.Speeds
+	return s
+}
+
+// Return the direction a greedy bot takes toward the first data point, avoiding the border, walls and its body
+func BotInput(s *sim.Simulation) sim.Direction {
+	x, y := s.DataPoints[0].Position()
+	headX, headY := s.Snake.Body[0][0], s.Snake.Body[0][1]
+	var candidates []sim.Direction
+	if x > headX {
+		candidates = append(candidates, sim.DirRight)
+	}
+	if x < headX {
+		candidates = append(candidates, sim.DirLeft)
+	}
+	if y > headY {
+		candidates = append(candidates, sim.DirDown)
+	}
+	if y < headY {
+		candidates = append(candidates, sim.DirUp)
+	}
+	candidates = append(candidates, sim.DirUp, sim.DirDown, sim.DirLeft, sim.DirRight)
+
+	// Steer against the effects mapping the input, such as a reversed control
+	var effects []sim.Effect
+	for _, active := range s.ActiveEffects {
+		if effect, err := sim.LookupEffect(active.Slug); err == nil && effect.Input != nil {
+			effects = append(effects, effect)
+		}
+	}
+	for _, dir := range candidates {
+		if s.CurrentDir.IsOpposite(dir) {
+			continue
+		}
+		moveX, moveY := dir.Vector()
+		nextX, nextY := headX+float32(moveX), headY+float32(moveY)
+		if nextX < sim.GridX1 || nextX >= sim.GridX2 || nextY < sim.GridY1 || nextY >= sim.GridY2 {
+			continue
+		}
+		if s.Snake.CollidesWithItself(nextX, nextY) || s.Walls.IsWall(nextX, nextY) {
+			continue
+		}
+		return unmap(dir, effects)
+	}
+	return sim.DirNone
+}
+
+// Return the input the effects map to dir
+func unmap(dir sim.Direction, effects []sim.Effect) sim.Direction {
+	if len(effects) == 0 {
+		return dir
+	}
+	for _, input := range []sim.Direction{sim.DirUp, sim.DirDown, sim.DirLeft, sim.DirRight} {
+		mapped := input
+		for _, effect := range effects {
+			mapped = effect.Input(mapped)
+		}
+		if mapped == dir {
+			return input
+		}
+	}
+	return dir
+}
+
+// Play a run with the bot until it ends or maxSteps, acknowledging every special data point and calling check after every step
+func Play(s *sim.Simulation, maxSteps int, check func(events sim.Events)) {
+	for s.Steps < maxSteps && (s.State == sim.PlayState || s.State == sim.SpecialState) {
+		s.Resume()
+		events := s.Step(BotInput(s))
+		if check != nil {
+			check(events)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package sim
+
+import (
+	"math/rand"
+)
+
+type GameState int
+
+const (
+	WelcomeState GameState = iota
+	PlayState
+	GameOverState
+	SpecialState
+	GoalState
+	BlinkState
+	InitialsState
+	HighScoresState
+	CodexState
+	QuizState
+	EditorState
+)
+
+// Define the headless game rules, free of any Ebiten, clock or global rand dependency
+type Simulation struct {
+	Snake                    Snake
+	CurrentDir               Direction // Current direction of the snake
+	initialSpecialDataPoints []SpecialDataPoint
+	layouts                  Layouts
+	behaviors                Behaviors
+	regulation               Regulation
+	Walls                    Layout         // Walls of the current level
+	Arena                    *Arena         // Custom arena replacing the walls of every level and the spawn, nil for none
+	Regulator                *Regulator     // Regulator hunting the snake, nil until its level is reached
+	ActiveEffects            []ActiveEffect // Effects of the power-ups collected, until they wear off
+	SpecialDataPoints        []SpecialDataPoint
+	DataPoints               []DataPointInterface // Data points on the board
+	CurrentSpecialDataPoint  SpecialDataPoint
+	LastSpecialDataPoint     bool
+	State                    GameState
+	Score                    int64
+	Breakdown                ScoreBreakdown
+	Pickups                  int             // Data points collected, driving the special data point cadence
+	Acquisitions             int             // Special data points collected
+	Acquired                 []Acquisition   // Special data points collected in the current run, in capture order
+	timeline                 []TimelineEntry // Acquired ordered by year, rebuilt on each acquisition
+	Streak                   int             // Current run of quick pickups
+	movesSincePickup         int
+	Level                    string
+	LevelChanges             int         // Level changes of the current run, speeding the snake up
+	Speeds                   SpeedCurves // Speed curve of each difficulty, falling back to DefaultSpeedCurves
+	PlayTime                 float64     // Seconds played in the current run, each step lasting 1/Speed()
+	Seed                     int64       // Seed of the data point placement of the current run
+	CampaignID               string      // Campaign the special data points come from
+	Difficulty               Difficulty
+	Rules                    Rules
+	Steps                    int    // Steps taken in the current run
+	Turns                    []Turn // Direction changes of the current run, to re-simulate it
+	Borderless               bool   // Wrap the snake to the opposite edge instead of ending the run on the border
+	QuizMode                 bool   // Ask questions about the acquisitions between levels and once the run is over
+	Quiz                     *Quiz  // Quiz in progress, nil outside of QuizState
+	QuizAnswers              []QuizAnswer
+	quizDue                  bool // The level changed on the last acquisition
+	rng                      *rand.Rand
+}
+
+// Report what happened during a single simulation step
+type Events struct {
+	Moved        bool   // The snake advanced by one cell
+	Collected    bool   // A data point was collected
+	Points       int64  // Points awarded for the collected data point
+	Special      bool   // The collected data point was a special one
+	LevelChanged bool   // The level changed after a special pickup
+	GameOver     bool   // The snake hit the border or itself, or was caught by the regulator
+	Regulated    bool   // The regulator caught the snake
+	Fine         int64  // Points fined by the regulator
+	Goal         bool   // The last special data point was collected
+	PowerUp      string // Effect of the collected power-up, empty for none
+	Expired      bool   // The data point expired and showed up elsewhere
+}
+
+// Initialize and return a new simulation in PlayState, placing data points from seed around the walls of layouts, behaving as behaviors set by level
+func NewSimulation(specialDataPoints []SpecialDataPoint, layouts Layouts, behaviors Behaviors, regulation Regulation, seed int64, difficulty Difficulty) *Simulation {
+	// Deep copy specialDataPoints to initialSpecialDataPoints
+	initialSpecialDataPoints := make([]SpecialDataPoint, len(specialDataPoints))
+	copy(initialSpecialDataPoints, specialDataPoints)
+
+	s := &Simulation{
+		initialSpecialDataPoints: initialSpecialDataPoints,
+		layouts:                  layouts,
+		behaviors:                behaviors,
+		regulation:               regulation,
+		Seed:                     seed,
+		Difficulty:               difficulty,
+		Rules:                    difficulty.Rules(),
+	}
+	s.Reset()
+	return s
+}
+
+// Put the simulation back to the start of a new game, replaying the layout of Seed
+func (s *Simulation) Reset() {
+	s.rng = rand.New(rand.NewSource(s.Seed))
+	spawn := DefaultSpawn
+	if s.Arena != nil {
+		spawn = s.Arena.Spawn
+	}
+	s.Snake = NewSnake(spawn)
+	s.CurrentDir = spawn.Dir
+	s.CurrentSpecialDataPoint = SpecialDataPoint{}
+	s.LastSpecialDataPoint = false
+	s.State = PlayState
+	s.Score = 0
+	s.Breakdown = ScoreBreakdown{}
+	s.Pickups = 0
+	s.Acquisitions = 0
+	s.Acquired = nil
+	s.timeline = nil
+	s.Regulator = nil
+	s.ActiveEffects = nil
+	s.Streak = 0
+	s.movesSincePickup = 0
+	s.Steps = 0
+	s.LevelChanges = 0
+	s.PlayTime = 0
+	s.Turns = nil
+	s.Quiz = nil
+	s.QuizAnswers = nil
+	s.quizDue = false
+
+	// Reset specialDataPoints to their initial state
+	s.SpecialDataPoints = make([]SpecialDataPoint, len(s.initialSpecialDataPoints))
+	copy(s.SpecialDataPoints, s.initialSpecialDataPoints)
+	if len(s.initialSpecialDataPoints) > 0 {
+		s.Level = s.initialSpecialDataPoints[0].Level
+	}
+	s.DataPoints = nil
+	s.buildWalls()
+	s.fillDataPoints()
+}
+
+// Go back to PlayState once a special data point has been acknowledged, through a quiz if the level changed
+func (s *Simulation) Resume() {
+	if s.State == SpecialState {
+		s.State = PlayState
+		if s.quizDue {
+			s.quizDue = false
+			s.startQuiz(LevelQuizQuestions, PlayState)
+		}
+	}
+}
+
+// Advance the snake by one cell, turning first if input is a valid direction
+func (s *Simulation) Step(input Direction) Events {
+	var events Events
+	if s.State != PlayState {
+		return events
+	}
+
+	// Apply the effects in progress, logging the input as given so re-simulations map it again
+	dir := input
+	passesThrough := false
+	for _, effect := range s.effects() {
+		if effect.Input != nil && dir != DirNone {
+			dir = effect.Input(dir)
+		}
+		passesThrough = passesThrough || effect.PassesThrough
+	}
+	if dir != DirNone && dir != s.CurrentDir && !s.CurrentDir.IsOpposite(dir) {
+		s.CurrentDir = dir
+		s.Turns = append(s.Turns, Turn{Step: s.Steps, Dir: input})
+	}
+	s.PlayTime += 1 / s.Speed()
+	s.Steps++
+	s.expireEffects()
+
+	// Calculate the new head position
+	moveX, moveY := s.CurrentDir.Vector()
+	headX, headY := s.Snake.Body[0][0], s.Snake.Body[0][1]
+	nextHeadX := headX + float32(moveX)
+	nextHeadY := headY + float32(moveY)
+
+	// Wrap to the opposite edge in borderless mode, or check collision with play area border
+	if s.Borderless {
+		nextHeadX, nextHeadY = wrap(nextHeadX, GridX1, GridX2), wrap(nextHeadY, GridY1, GridY2)
+	} else if nextHeadX < GridX1 || nextHeadX >= GridX2 || nextHeadY < GridY1 || nextHeadY >= GridY2 {
+		s.State = GameOverState
+		s.startQuiz(FinalQuizQuestions, GameOverState)
+		events.GameOver = true
+		return events
+	}
+
+	// Check collision with itself, unless an effect lets it through, or a wall of the level
+	if (!passesThrough && s.Snake.CollidesWithItself(nextHeadX, nextHeadY)) || s.Walls.IsWall(nextHeadX, nextHeadY) {
+		s.State = GameOverState
+		s.startQuiz(FinalQuizQuestions, GameOverState)
+		events.GameOver = true
+		return events
+	}
+
+	// Move the snake and handle collision with the current data point
+	s.handleSnakeMovementAndCollision(nextHeadX, nextHeadY, &events)
+	events.Moved = true
+	s.regulate(&events)
+	s.updateDataPoints(&events)
+	return events
+}
+
+// Bring a coordinate that left the play area back in from the opposite edge
+func wrap(v, low, high float32) float32 {
+	if v < low {
+		return high - 1
+	}
+	if v >= high {
+		return low
+	}
+	return v
+}
+
+// Generate the data point replacing a collected one, special or a power-up on their cadence unless one is already on the board
+func (s *Simulation) generateDataPoint() DataPointInterface {
+	_, specialOnBoard := s.findDataPoint(isSpecial)
+	_, powerUpOnBoard := s.findDataPoint(isPowerUp)
+	if len(s.SpecialDataPoints) > 0 && s.Pickups%SpecialDataPointsRate == 0 && !specialOnBoard {
+		// Use the first special data point
+		special := NewSpecialDataPoint(s.Snake, s.occupied(), s.SpecialDataPoints[0], s.rng)
+		s.SpecialDataPoints = s.SpecialDataPoints[1:]
+
+		// Check if special data points have run out
+		if len(s.SpecialDataPoints) == 0 {
+			s.LastSpecialDataPoint = true
+		}
+		return special
+	} else if len(PowerUpEffects) > 0 && s.Pickups%PowerUpRate == 0 && !powerUpOnBoard {
+		// Generate a power-up of a random effect
+		return NewPowerUp(s.Snake, s.occupied(), s.rng)
+	}
+	// Generate a regular data point
+	return s.newRegularDataPoint()
+}
+
+// Add regular data points until the board holds as many as the current level sets
+func (s *Simulation) fillDataPoints() {
+	for len(s.DataPoints) < s.Behavior().count() {
+		s.DataPoints = append(s.DataPoints, s.newRegularDataPoint())
+	}
+}
+
+// Return the index of the first data point on the board matching match
+func (s *Simulation) findDataPoint(match func(DataPointInterface) bool) (int, bool) {
+	for i, dp := range s.DataPoints {
+		if match(dp) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func isRegular(dp DataPointInterface) bool {
+	return !isSpecial(dp) && !isPowerUp(dp)
+}
+
+func isSpecial(dp DataPointInterface) bool {
+	_, special := dp.(SpecialDataPoint)
+	return special
+}
+
+func isPowerUp(dp DataPointInterface) bool {
+	_, powerUp := dp.(PowerUp)
+	return powerUp
+}
+
+// Return the cells data points cannot show up on: the walls, the cells of the data points on the board and the regulator
+func (s *Simulation) occupied() Layout {
+	occupied := Layout{}
+	for cell := range s.Walls {
+		occupied[cell] = true
+	}
+	for _, dp := range s.DataPoints {
+		x, y := dp.Position()
+		occupied[[2]float32{x, y}] = true
+	}
+	if s.Regulator != nil {
+		occupied[[2]float32{s.Regulator.X, s.Regulator.Y}] = true
+	}
+	return occupied
+}
+
+func (s *Simulation) handleSnakeMovementAndCollision(nextHeadX, nextHeadY float32, events *Events) {
+	s.movesSincePickup++
+	if i, found := s.findDataPoint(func(dp DataPointInterface) bool { return dp.IsColliding(s.Snake) }); found {
+		// Collision detected, increase score
+		collected := s.DataPoints[i]
+		s.DataPoints = append(s.DataPoints[:i:i], s.DataPoints[i+1:]...)
+		events.Points = s.scorePickup(collected)
+		events.Collected = true
+		collected.OnCollect(s, events)
+
+		// Replace it after handling the current collision, filling the board up to the count of the level
+		dp := s.generateDataPoint()
+		if len(s.DataPoints) < s.Behavior().count() {
+			s.DataPoints = append(s.DataPoints, dp)
+			s.fillDataPoints()
+		} else if !isRegular(dp) {
+			// The level has fewer data points, a special or a power-up still shows up on its cadence in place of a regular one
+			if j, found := s.findDataPoint(isRegular); found {
+				s.DataPoints[j] = dp
+			} else {
+				s.DataPoints = append(s.DataPoints, dp)
+			}
+		}
+	}
+
+	// Add the new head position and handle snake growth
+	s.Snake.Move(nextHeadX, nextHeadY)
+}
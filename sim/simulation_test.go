@@ -0,0 +1,72 @@
+package sim_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/sim/simtest"
+)
+
+func TestStepIsDeterministic(t *testing.T) {
+	campaign := simtest.LoadCampaign(t, "google")
+	for seed := int64(1); seed <= 20; seed++ {
+		a := simtest.NewSimulation(campaign, seed)
+		b := simtest.NewSimulation(campaign, seed)
+		for a.Steps < 2000 && a.State != sim.GameOverState && a.State != sim.GoalState {
+			a.Resume()
+			b.Resume()
+			input := simtest.BotInput(a)
+			if eventsA, eventsB := a.Step(input), b.Step(input); eventsA != eventsB {
+				t.Fatalf("seed %d step %d: events differ: %+v and %+v", seed, a.Steps, eventsA, eventsB)
+			}
+		}
+		if !reflect.DeepEqual(a.Snapshot(), b.Snapshot()) {
+			t.Fatalf("seed %d: simulations differ after %d steps", seed, a.Steps)
+		}
+	}
+}
+
+func TestBorderEndsTheRun(t *testing.T) {
+	s := sim.NewSimulation(nil, nil, nil, sim.Regulation{}, 1, sim.Normal)
+	s.DataPoints = nil
+	headX := s.Snake.Body[0][0]
+	for step := 0; step < int(sim.GridX2-headX)-1; step++ {
+		if events := s.Step(sim.DirRight); events.GameOver {
+			t.Fatalf("run ended at step %d, before reaching the border", step)
+		}
+	}
+	if events := s.Step(sim.DirRight); !events.GameOver || s.State != sim.GameOverState {
+		t.Fatalf("expected the border to end the run, got state %d and events %+v", s.State, events)
+	}
+}
+
+func TestBorderlessWrapsAround(t *testing.T) {
+	s := sim.NewSimulation(nil, nil, nil, sim.Regulation{}, 1, sim.Normal)
+	s.DataPoints = nil
+	s.Borderless = true
+	headX := s.Snake.Body[0][0]
+	for step := 0; step < int(sim.GridX2-headX); step++ {
+		if events := s.Step(sim.DirRight); events.GameOver {
+			t.Fatalf("run ended at step %d in borderless mode", step)
+		}
+	}
+	if s.Snake.Body[0][0] != sim.GridX1 {
+		t.Fatalf("expected the head to wrap to column %v, got %v", sim.GridX1, s.Snake.Body[0][0])
+	}
+}
+
+func TestWallEndsTheRun(t *testing.T) {
+	s := sim.NewSimulation(nil, nil, nil, sim.Regulation{}, 1, sim.Normal)
+	s.DataPoints = nil
+	moveX, moveY := s.CurrentDir.Vector()
+	headX, headY := s.Snake.Body[0][0], s.Snake.Body[0][1]
+	s.Walls = sim.Layout{{headX + 2*float32(moveX), headY + 2*float32(moveY)}: true}
+
+	if events := s.Step(sim.DirNone); events.GameOver {
+		t.Fatalf("run ended before reaching the wall")
+	}
+	if events := s.Step(sim.DirNone); !events.GameOver || s.State != sim.GameOverState {
+		t.Fatalf("expected the wall to end the run, got state %d and events %+v", s.State, events)
+	}
+}
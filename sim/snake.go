@@ -0,0 +1,105 @@
+package sim
+
+// Define the snake with a slice of x,y coordinates for its body
+type Snake struct {
+	Body          [][2]float32
+	PendingGrowth int // Cells still to grow, one per move
+}
+
+// Possible directions the snake can move in
+type Direction int
+
+// Enumeration of directions
+const (
+	DirUp Direction = iota
+	DirDown
+	DirLeft
+	DirRight
+	DirNone // No direction change requested
+)
+
+// Check if the given direction is opposite to the current direction
+func (d Direction) IsOpposite(other Direction) bool {
+	switch d {
+	case DirUp:
+		return other == DirDown
+	case DirDown:
+		return other == DirUp
+	case DirLeft:
+		return other == DirRight
+	case DirRight:
+		return other == DirLeft
+	}
+	return false
+}
+
+// Return the direction opposite to d
+func (d Direction) Opposite() Direction {
+	switch d {
+	case DirUp:
+		return DirDown
+	case DirDown:
+		return DirUp
+	case DirLeft:
+		return DirRight
+	case DirRight:
+		return DirLeft
+	}
+	return d
+}
+
+// Return the unit vector (as x, y increments) for the given direction
+func (d Direction) Vector() (int, int) {
+	switch d {
+	case DirUp:
+		return 0, -1
+	case DirDown:
+		return 0, 1
+	case DirLeft:
+		return -1, 0
+	case DirRight:
+		return 1, 0
+	}
+	return 0, 0
+}
+
+// Define where the head of the snake starts and the direction it heads to
+type Spawn struct {
+	X, Y float32
+	Dir  Direction
+}
+
+// Spawn of the snake outside of custom arenas
+var DefaultSpawn = Spawn{X: GridX1 + 3, Y: GridY1 + 4, Dir: DirRight}
+
+// Initialize and return a new snake with a default length at spawn
+func NewSnake(spawn Spawn) Snake {
+	body := make([][2]float32, int(InitialSnakeLength))
+
+	// Align the body behind the head
+	moveX, moveY := spawn.Dir.Vector()
+	for i := 0; i < int(InitialSnakeLength); i++ {
+		body[i] = [2]float32{spawn.X - float32(i*moveX), spawn.Y - float32(i*moveY)}
+	}
+
+	return Snake{Body: body}
+}
+
+// Move the head to the given cell, keeping the tail while growth is pending
+func (s *Snake) Move(nextHeadX, nextHeadY float32) {
+	s.Body = append([][2]float32{{nextHeadX, nextHeadY}}, s.Body...)
+	if s.PendingGrowth > 0 {
+		s.PendingGrowth--
+	} else {
+		s.Body = s.Body[:len(s.Body)-1]
+	}
+}
+
+func (s *Snake) CollidesWithItself(nextHeadX, nextHeadY float32) bool {
+	for _, segment := range s.Body[1:] { // Start from 1 to skip the head
+		if segment[0] == nextHeadX && segment[1] == nextHeadY {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package sim_test
+
+import (
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+func TestSnakeGrowsOneCellPerMove(t *testing.T) {
+	snake := sim.NewSnake(sim.DefaultSpawn)
+	snake.PendingGrowth = 2
+	length := len(snake.Body)
+	for i, want := range []int{length + 1, length + 2, length + 2} {
+		snake.Move(snake.Body[0][0]+1, snake.Body[0][1])
+		if len(snake.Body) != want {
+			t.Fatalf("move %d: expected %d cells, got %d", i+1, want, len(snake.Body))
+		}
+	}
+	if snake.PendingGrowth != 0 {
+		t.Fatalf("expected no growth left, got %d", snake.PendingGrowth)
+	}
+}
+
+func TestGrowthFollowsRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		difficulty sim.Difficulty
+		collected  sim.DataPointInterface
+		want       int
+	}{
+		{"regular on easy", sim.Easy, sim.DataPoint{}, 1},
+		{"regular on normal", sim.Normal, sim.DataPoint{}, 2},
+		{"regular on nokia", sim.Nokia, sim.DataPoint{}, 3},
+		{"special on normal", sim.Normal, sim.SpecialDataPoint{Slug: "first", Level: "Start"}, 4},
+		{"special on nokia", sim.Nokia, sim.SpecialDataPoint{Slug: "first", Level: "Start"}, 6},
+		{"special with its own growth", sim.Easy, sim.SpecialDataPoint{Slug: "first", Level: "Start", Growth: 5}, 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			specials := []sim.SpecialDataPoint{{Slug: "first", Level: "Start"}, {Slug: "second", Level: "Start"}}
+			s := sim.NewSimulation(specials, nil, nil, sim.Regulation{}, 1, test.difficulty)
+
+			// Collect the data point under the head
+			headX, headY := s.Snake.Body[0][0], s.Snake.Body[0][1]
+			if special, ok := test.collected.(sim.SpecialDataPoint); ok {
+				special.X, special.Y = headX, headY
+				s.DataPoints = []sim.DataPointInterface{special}
+			} else {
+				s.DataPoints = []sim.DataPointInterface{sim.DataPoint{X: headX, Y: headY}}
+			}
+			if events := s.Step(sim.DirNone); !events.Collected {
+				t.Fatalf("data point under the head not collected: %+v", events)
+			}
+			// The move collecting it already grows the first cell
+			if grown := len(s.Snake.Body) - int(sim.InitialSnakeLength) + s.Snake.PendingGrowth; grown != test.want {
+				t.Fatalf("expected %d cells to grow, got %d", test.want, grown)
+			}
+		})
+	}
+}
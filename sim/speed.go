@@ -0,0 +1,47 @@
+package sim
+
+// Define how the speed of the snake, in moves per second, grows during a run
+type SpeedCurve struct {
+	Start     float64 // Speed at the start of a run
+	PerPickup float64 // Speed gained per data point collected
+	PerLevel  float64 // Speed gained on each level change
+	Max       float64 // Highest speed, 0 for none
+}
+
+// Define the speed curve of each difficulty
+type SpeedCurves map[Difficulty]SpeedCurve
+
+// Curves of the difficulty presets, used for every difficulty a campaign does not define
+var DefaultSpeedCurves = SpeedCurves{
+	Easy:   {Start: 5, PerPickup: 0.05, PerLevel: 0.5, Max: 9},
+	Normal: {Start: 7, PerPickup: 0.08, PerLevel: 1, Max: 12},
+	Nokia:  {Start: 9, PerPickup: 0.1, PerLevel: 1.5, Max: 16},
+}
+
+// Return the speed after the given pickups and level changes
+func (c SpeedCurve) Speed(pickups, levelChanges int) float64 {
+	speed := c.Start + c.PerPickup*float64(pickups) + c.PerLevel*float64(levelChanges)
+	if c.Max > 0 && speed > c.Max {
+		speed = c.Max
+	}
+	return speed
+}
+
+// Return the curve of difficulty, falling back to its default one
+func (c SpeedCurves) For(difficulty Difficulty) SpeedCurve {
+	if curve, found := c[difficulty]; found {
+		return curve
+	}
+	return DefaultSpeedCurves[difficulty]
+}
+
+// Return the current speed of the snake, in moves per second, slowed or sped up by the effects in progress
+func (s *Simulation) Speed() float64 {
+	speed := s.Speeds.For(s.Difficulty).Speed(s.Pickups, s.LevelChanges)
+	for _, effect := range s.effects() {
+		if effect.SpeedFactor > 0 {
+			speed *= effect.SpeedFactor
+		}
+	}
+	return speed
+}
@@ -0,0 +1,61 @@
+package sim_test
+
+import (
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+)
+
+func TestSpeedCurve(t *testing.T) {
+	curve := sim.SpeedCurve{Start: 5, PerPickup: 0.5, PerLevel: 2, Max: 10}
+	tests := []struct {
+		pickups, levelChanges int
+		want                  float64
+	}{
+		{0, 0, 5},
+		{4, 0, 7},
+		{0, 1, 7},
+		{2, 1, 8},
+		{20, 3, 10}, // Capped at Max
+	}
+	for _, test := range tests {
+		if got := curve.Speed(test.pickups, test.levelChanges); got != test.want {
+			t.Errorf("%d pickups and %d level changes: expected %g moves/s, got %g", test.pickups, test.levelChanges, test.want, got)
+		}
+	}
+
+	uncapped := sim.SpeedCurve{Start: 5, PerPickup: 1}
+	if got := uncapped.Speed(100, 0); got != 105 {
+		t.Errorf("expected a curve without Max to keep growing, got %g moves/s", got)
+	}
+}
+
+func TestSpeedCurvesFallBackToTheDefaultOnes(t *testing.T) {
+	custom := sim.SpeedCurve{Start: 3}
+	curves := sim.SpeedCurves{sim.Easy: custom}
+	if got := curves.For(sim.Easy); got != custom {
+		t.Errorf("expected the campaign curve for easy, got %+v", got)
+	}
+	if got := curves.For(sim.Nokia); got != sim.DefaultSpeedCurves[sim.Nokia] {
+		t.Errorf("expected the default curve for nokia, got %+v", got)
+	}
+	if got := sim.SpeedCurves(nil).For(sim.Normal); got != sim.DefaultSpeedCurves[sim.Normal] {
+		t.Errorf("expected the default curve without campaign curves, got %+v", got)
+	}
+}
+
+func TestSimulationSpeedsUpWithPickupsAndLevels(t *testing.T) {
+	s := sim.NewSimulation(nil, nil, nil, sim.Regulation{}, 1, sim.Normal)
+	s.Speeds = sim.SpeedCurves{sim.Normal: {Start: 6, PerPickup: 0.25, PerLevel: 1, Max: 8}}
+	if got := s.Speed(); got != 6 {
+		t.Fatalf("expected 6 moves/s at the start, got %g", got)
+	}
+	s.Pickups, s.LevelChanges = 4, 1
+	if got := s.Speed(); got != 8 {
+		t.Errorf("expected 8 moves/s, got %g", got)
+	}
+	s.Pickups = 40
+	if got := s.Speed(); got != 8 {
+		t.Errorf("expected the speed capped at 8 moves/s, got %g", got)
+	}
+}
@@ -0,0 +1,54 @@
+package sim
+
+import "sort"
+
+// Define a special data point collected during a run, and the step and play time it was collected at
+type Acquisition struct {
+	Slug string
+	Step int
+	Time float64 // Seconds played
+}
+
+// Define an acquisition of the run resolved to its special data point
+type TimelineEntry struct {
+	SpecialDataPoint
+	Step int
+	Time float64
+}
+
+// Return the acquisitions of the run ordered by year, ties kept in capture order
+func (s *Simulation) Timeline() []TimelineEntry {
+	return s.timeline
+}
+
+// Resolve and sort the acquisitions of the run into its timeline
+func (s *Simulation) buildTimeline() {
+	var timeline []TimelineEntry
+	for _, acquisition := range s.Acquired {
+		special, err := s.specialDataPoint(acquisition.Slug)
+		if err != nil {
+			continue
+		}
+		timeline = append(timeline, TimelineEntry{SpecialDataPoint: special, Step: acquisition.Step, Time: acquisition.Time})
+	}
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].Year < timeline[j].Year })
+	s.timeline = timeline
+}
+
+// Return the levels the run went through, in order, starting with the first one of the campaign
+func (s *Simulation) LevelPath() []string {
+	var path []string
+	if len(s.initialSpecialDataPoints) > 0 {
+		path = append(path, s.initialSpecialDataPoints[0].Level)
+	}
+	for _, acquisition := range s.Acquired {
+		special, err := s.specialDataPoint(acquisition.Slug)
+		if err != nil {
+			continue
+		}
+		if len(path) == 0 || path[len(path)-1] != special.Level {
+			path = append(path, special.Level)
+		}
+	}
+	return path
+}
@@ -0,0 +1,50 @@
+package sim_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/szkjn/snakeopoly-go/sim"
+	"github.com/szkjn/snakeopoly-go/sim/simtest"
+)
+
+func TestTimelineOrdersAcquisitionsByYear(t *testing.T) {
+	s := simtest.NewSimulation(simtest.LoadCampaign(t, "google"), 1)
+	simtest.Play(s, 3000, func(events sim.Events) {
+		if !events.Special {
+			return
+		}
+		// The entry of the acquisition carries the step it was captured at
+		acquisition := s.Acquired[len(s.Acquired)-1]
+		found := false
+		for _, entry := range s.Timeline() {
+			found = found || entry.Slug == acquisition.Slug && entry.Step == s.Steps && entry.Time == acquisition.Time
+		}
+		if !found {
+			t.Fatalf("step %d: %q missing from the timeline", s.Steps, acquisition.Slug)
+		}
+	})
+
+	timeline := s.Timeline()
+	if len(timeline) < 2 || len(timeline) != len(s.Acquired) {
+		t.Fatalf("expected an entry per acquisition (%d), got %d", len(s.Acquired), len(timeline))
+	}
+	for i := 1; i < len(timeline); i++ {
+		if timeline[i].Year < timeline[i-1].Year {
+			t.Errorf("entry %d (%d) comes after a later year (%d)", i, timeline[i].Year, timeline[i-1].Year)
+		}
+	}
+
+	// Restoring the run restores its timeline, and resetting clears it
+	restored := simtest.NewSimulation(simtest.LoadCampaign(t, "google"), 0)
+	if err := restored.Restore(s.Snapshot()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(restored.Timeline(), timeline) {
+		t.Error("expected the restored run to have the same timeline")
+	}
+	s.Reset()
+	if len(s.Timeline()) != 0 {
+		t.Errorf("expected an empty timeline after reset, got %d entries", len(s.Timeline()))
+	}
+}
@@ -0,0 +1,88 @@
+package typeset
+
+import (
+	"strings"
+
+	"github.com/szkjn/snakeopoly-go/assets/fonts"
+	"golang.org/x/image/font"
+)
+
+// Size of the screen and of the unit the pages are laid out in, in pixels
+const (
+	ScreenWidth = 800
+	ScreenUnit  = ScreenWidth / 25
+)
+
+// Constants related to the text of the special page
+const (
+	SpecialTextWidth    int = ScreenWidth - 11*ScreenUnit // Wrap width in pixels
+	SpecialTextMaxLines int = 6                           // Lines fitting above the score
+	FactLineWidth       int = ScreenWidth - 2*ScreenUnit  // Width of the summary and sources lines
+	CodexFactWidth      int = 6 * ScreenUnit              // Width of the category and price under the codex icon
+)
+
+// Fonts the special and codex pages draw their texts with
+var (
+	FontM font.Face = fonts.MustLoadFace(ScreenUnit * 1)
+	FontS font.Face = fonts.MustLoadFace(ScreenUnit * 0.7)
+)
+
+// Split the quoted text into lines no wider than maxLineWidth
+func WrapText(textStr string, fontFace font.Face, maxLineWidth int) []string {
+	// Add double quotes at the beginning and end of the textStr
+	textStr = "\"" + textStr + "\""
+
+	// Split the text into words
+	words := strings.Fields(textStr)
+	var lines []string
+	var currentLine string
+
+	for _, word := range words {
+		// Check line width with the new word added
+		testLine := currentLine
+		if currentLine != "" {
+			testLine += " " // Add a space before the word if it's not the first word in the line
+		}
+		testLine += word
+
+		if TextWidth(fontFace, testLine) <= maxLineWidth {
+			// If it fits, add the word to the current line
+			currentLine = testLine
+		} else {
+			// If it doesn't fit, start a new line
+			if currentLine != "" {
+				lines = append(lines, currentLine)
+			}
+			currentLine = word
+		}
+	}
+
+	// Add the last line
+	lines = append(lines, currentLine)
+	return lines
+}
+
+// Return the width of textStr drawn with fontFace, in pixels
+func TextWidth(fontFace font.Face, textStr string) int {
+	bounds, _ := font.BoundString(fontFace, textStr)
+	return (bounds.Max.X - bounds.Min.X).Ceil()
+}
+
+// Check if a special data point text fits the special page
+func SpecialTextFits(textStr string) bool {
+	return len(WrapText(textStr, FontM, SpecialTextWidth)) <= SpecialTextMaxLines
+}
+
+// Check if the value of a special data points CSV column fits where the special and codex pages draw it
+func ContentFits(column, textStr string) bool {
+	if column == "text" {
+		return SpecialTextFits(textStr)
+	} else if column == "summary" {
+		return TextWidth(FontS, textStr) <= FactLineWidth
+	} else if column == "sources" {
+		return TextWidth(FontS, textStr) <= FactLineWidth
+	} else if column == "category" || column == "price" {
+		return TextWidth(FontS, textStr) <= CodexFactWidth
+	}
+	return true
+}